@@ -9,8 +9,10 @@ import (
 	elastic "github.com/elastic/go-elasticsearch/v8"
 
 	"github.com/oSoloTurk/multiple-kind-search/internal/config"
+	"github.com/oSoloTurk/multiple-kind-search/internal/domain"
 	"github.com/oSoloTurk/multiple-kind-search/internal/handler"
 	"github.com/oSoloTurk/multiple-kind-search/internal/logger"
+	"github.com/oSoloTurk/multiple-kind-search/internal/notifier"
 	"github.com/oSoloTurk/multiple-kind-search/internal/repository/elasticsearch"
 	"github.com/oSoloTurk/multiple-kind-search/internal/service"
 	"github.com/spf13/cobra"
@@ -45,20 +47,42 @@ func runAPI(cmd *cobra.Command, args []string) {
 		log.Fatalf("Failed to create Elasticsearch client: %v", err)
 	}
 
+	// Alerts are only pushed out when a webhook is configured
+	var alertNotifier domain.AlertNotifier
+	if cfg.AlertWebhookURL != "" {
+		alertNotifier = notifier.NewWebhookNotifier(cfg.AlertWebhookURL)
+	}
+
 	// Initialize repositories
 	authorRepo := elasticsearch.NewAuthorRepository(esClient)
-	newsRepo := elasticsearch.NewNewsRepository(esClient)
-	searchRepo := elasticsearch.NewSearchRepository(esClient)
+	alertRepo := elasticsearch.NewAlertRepository(esClient, alertNotifier)
+	newsRepo := elasticsearch.NewNewsRepository(esClient, alertRepo)
+	searchKinds := elasticsearch.NewKindRegistry(
+		elasticsearch.NewAuthorKind(),
+		elasticsearch.NewNewsKind(),
+	)
+	searchRepo := elasticsearch.NewSearchRepository(esClient, searchKinds)
+	synonymRepo := elasticsearch.NewSynonymRepository(esClient)
+	analyticsRepo := elasticsearch.NewAnalyticsRepository(esClient)
+	savedSearchRepo := elasticsearch.NewSavedSearchRepository(esClient)
 
 	// Initialize services
 	authorService := service.NewAuthorService(authorRepo)
 	newsService := service.NewNewsService(newsRepo)
-	searchService := service.NewSearchService(searchRepo)
+	searchService := service.NewSearchService(searchRepo, analyticsRepo)
+	synonymService := service.NewSynonymService(synonymRepo)
+	analyticsService := service.NewAnalyticsService(analyticsRepo)
+	savedSearchService := service.NewSavedSearchService(savedSearchRepo)
+	alertService := service.NewAlertService(alertRepo)
 
 	// Initialize handlers
 	authorHandler := handler.NewAuthorHandler(authorService)
 	newsHandler := handler.NewNewsHandler(newsService)
 	searchHandler := handler.NewSearchHandler(searchService)
+	synonymHandler := handler.NewSynonymHandler(synonymService)
+	analyticsHandler := handler.NewAnalyticsHandler(analyticsService)
+	savedSearchHandler := handler.NewSavedSearchHandler(savedSearchService)
+	alertHandler := handler.NewAlertHandler(alertService)
 
 	// Initialize Fiber app
 	app := fiber.New()
@@ -91,6 +115,7 @@ func runAPI(cmd *cobra.Command, args []string) {
 
 	// Search routes
 	api.Get("/search", searchHandler.Search)
+	api.Get("/suggest", searchHandler.Suggest)
 
 	// Domain routes
 	authors := api.Group("/authors")
@@ -104,9 +129,34 @@ func runAPI(cmd *cobra.Command, args []string) {
 	news.Post("/", newsHandler.Create)
 	news.Get("/", newsHandler.List)
 	news.Get("/:id", newsHandler.GetByID)
+	news.Get("/:id/related", newsHandler.Related)
 	news.Put("/:id", newsHandler.Update)
 	news.Delete("/:id", newsHandler.Delete)
 
+	synonyms := api.Group("/synonyms")
+	synonyms.Get("/", synonymHandler.List)
+	synonyms.Post("/", synonymHandler.Create)
+	synonyms.Get("/versions", synonymHandler.ListVersions)
+	synonyms.Post("/versions/:version/rollback", synonymHandler.Rollback)
+	synonyms.Get("/:id", synonymHandler.GetByID)
+	synonyms.Put("/:id", synonymHandler.Update)
+	synonyms.Delete("/:id", synonymHandler.Delete)
+
+	analytics := api.Group("/analytics")
+	analytics.Post("/clicks", analyticsHandler.RecordClick)
+	analytics.Get("/top-queries", analyticsHandler.TopQueries)
+	analytics.Get("/zero-results", analyticsHandler.ZeroResultQueries)
+	analytics.Get("/click-through", analyticsHandler.ClickThrough)
+
+	savedSearches := api.Group("/saved-searches")
+	savedSearches.Post("/", savedSearchHandler.Create)
+	savedSearches.Get("/", savedSearchHandler.List)
+	savedSearches.Get("/:id", savedSearchHandler.GetByID)
+	savedSearches.Put("/:id", savedSearchHandler.Update)
+	savedSearches.Delete("/:id", savedSearchHandler.Delete)
+
+	api.Get("/alerts", alertHandler.List)
+
 	logger.Logger.Info().Msgf("Starting API on port %s", cfg.ServerPort)
 	if err := app.Listen(":" + cfg.ServerPort); err != nil {
 		logger.Logger.Fatal().Err(err).Msg("Server failed to start")
@@ -15,9 +15,9 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/authors": {
+        "/api/alerts": {
             "get": {
-                "description": "Get a list of all authors",
+                "description": "Get a page of the alerts raised by articles matching saved searches, newest first",
                 "consumes": [
                     "application/json"
                 ],
@@ -25,16 +25,43 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "authors"
+                    "alerts"
+                ],
+                "summary": "List alerts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only alerts raised by this saved search",
+                        "name": "savedSearchId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of alerts per page (max 100)",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor returned as next by the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
                 ],
-                "summary": "List all authors",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.Author"
+                            "$ref": "#/definitions/domain.AlertPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
@@ -46,11 +73,22 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
-            },
-            "post": {
-                "description": "Create a new author with the provided details",
+            }
+        },
+        "/api/analytics/click-through": {
+            "get": {
+                "description": "Get the share of searches with at least one clicked result, overall and for the most searched queries",
                 "consumes": [
                     "application/json"
                 ],
@@ -58,25 +96,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "authors"
+                    "analytics"
                 ],
-                "summary": "Create a new author",
+                "summary": "Report the click-through rate",
                 "parameters": [
                     {
-                        "description": "Author details",
-                        "name": "author",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.Author"
-                        }
+                        "type": "string",
+                        "description": "Only events at or after this RFC 3339 time or YYYY-MM-DD date",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events at or before this RFC 3339 time or YYYY-MM-DD date",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of queries to report (max 100)",
+                        "name": "size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Author"
+                            "$ref": "#/definitions/domain.ClickThroughReport"
                         }
                     },
                     "400": {
@@ -96,13 +144,22 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
             }
         },
-        "/api/authors/{id}": {
-            "get": {
-                "description": "Get an author's details by their ID",
+        "/api/analytics/clicks": {
+            "post": {
+                "description": "Record that a result of a search was opened, the search given by the searchId of its response",
                 "consumes": [
                     "application/json"
                 ],
@@ -110,23 +167,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "authors"
+                    "analytics"
                 ],
-                "summary": "Get an author by ID",
+                "summary": "Record a click on a search result",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Author ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Clicked result and its search",
+                        "name": "click",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ClickEvent"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/domain.Author"
+                            "$ref": "#/definitions/domain.ClickEvent"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "404": {
@@ -137,11 +205,31 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
-            },
-            "put": {
-                "description": "Update an existing author's details",
+            }
+        },
+        "/api/analytics/top-queries": {
+            "get": {
+                "description": "Count searches per query, lowercased with white space collapsed, most frequent first",
                 "consumes": [
                     "application/json"
                 ],
@@ -149,32 +237,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "authors"
+                    "analytics"
                 ],
-                "summary": "Update an author",
+                "summary": "Report the most frequent queries",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Author ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Only searches at or after this RFC 3339 time or YYYY-MM-DD date",
+                        "name": "from",
+                        "in": "query"
                     },
                     {
-                        "description": "Updated author details",
-                        "name": "author",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.Author"
-                        }
+                        "type": "string",
+                        "description": "Only searches at or before this RFC 3339 time or YYYY-MM-DD date",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of queries to report (max 100)",
+                        "name": "size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Author"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.QueryCount"
+                            }
                         }
                     },
                     "400": {
@@ -194,11 +288,22 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete an author by their ID",
+            }
+        },
+        "/api/analytics/zero-results": {
+            "get": {
+                "description": "Count searches whose query completed without any result per query, including those autocorrect rescued, most frequent first",
                 "consumes": [
                     "application/json"
                 ],
@@ -206,21 +311,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "authors"
+                    "analytics"
                 ],
-                "summary": "Delete an author",
+                "summary": "Report the queries without results",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Author ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Only searches at or after this RFC 3339 time or YYYY-MM-DD date",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only searches at or before this RFC 3339 time or YYYY-MM-DD date",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of queries to report (max 100)",
+                        "name": "size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.QueryCount"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
@@ -230,13 +362,22 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
             }
         },
-        "/api/news": {
+        "/api/authors": {
             "get": {
-                "description": "Get a list of all news articles",
+                "description": "Get a page of authors, newest first",
                 "consumes": [
                     "application/json"
                 ],
@@ -244,16 +385,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "news"
+                    "authors"
+                ],
+                "summary": "List authors",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of authors per page (max 100)",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor returned as next by the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
                 ],
-                "summary": "List all news articles",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.News"
+                            "$ref": "#/definitions/domain.AuthorPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
@@ -265,11 +427,20 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
             },
             "post": {
-                "description": "Create a new news article with the provided details",
+                "description": "Create a new author with the provided details",
                 "consumes": [
                     "application/json"
                 ],
@@ -277,17 +448,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "news"
+                    "authors"
                 ],
-                "summary": "Create a new news article",
+                "summary": "Create a new author",
                 "parameters": [
                     {
-                        "description": "News article details",
-                        "name": "news",
+                        "description": "Author details",
+                        "name": "author",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.News"
+                            "$ref": "#/definitions/domain.Author"
                         }
                     }
                 ],
@@ -295,7 +466,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/domain.News"
+                            "$ref": "#/definitions/domain.Author"
                         }
                     },
                     "400": {
@@ -315,13 +486,22 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
             }
         },
-        "/api/news/{id}": {
+        "/api/authors/{id}": {
             "get": {
-                "description": "Get a news article's details by its ID",
+                "description": "Get an author's details by their ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -329,13 +509,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "news"
+                    "authors"
                 ],
-                "summary": "Get a news article by ID",
+                "summary": "Get an author by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "News ID",
+                        "description": "Author ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -345,7 +525,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.News"
+                            "$ref": "#/definitions/domain.Author"
                         }
                     },
                     "404": {
@@ -356,36 +536,54 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
-                    }
-                }
-            },
-            "put": {
-                "description": "Update an existing news article's details",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "news"
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update an existing author's details",
+                "consumes": [
+                    "application/json"
                 ],
-                "summary": "Update a news article",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "authors"
+                ],
+                "summary": "Update an author",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "News ID",
+                        "description": "Author ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated news article details",
-                        "name": "news",
+                        "description": "Updated author details",
+                        "name": "author",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.News"
+                            "$ref": "#/definitions/domain.Author"
                         }
                     }
                 ],
@@ -393,7 +591,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.News"
+                            "$ref": "#/definitions/domain.Author"
                         }
                     },
                     "400": {
@@ -405,6 +603,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -413,11 +620,20 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
             },
             "delete": {
-                "description": "Delete a news article by its ID",
+                "description": "Delete an author by their ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -425,13 +641,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "news"
+                    "authors"
                 ],
-                "summary": "Delete a news article",
+                "summary": "Delete an author",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "News ID",
+                        "description": "Author ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -441,6 +657,15 @@ const docTemplate = `{
                     "204": {
                         "description": "No Content"
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -449,13 +674,22 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
             }
         },
-        "/api/search": {
+        "/api/news": {
             "get": {
-                "description": "Search news content with boosted results for specified author",
+                "description": "Get a page of news articles, newest first",
                 "consumes": [
                     "application/json"
                 ],
@@ -463,33 +697,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "search"
+                    "news"
                 ],
-                "summary": "Search news with author boosting",
+                "summary": "List news articles",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Search query",
-                        "name": "q",
-                        "in": "query",
-                        "required": true
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of news articles per page (max 100)",
+                        "name": "size",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Author username to boost results for",
-                        "name": "username",
-                        "in": "query",
-                        "required": true
+                        "description": "Opaque cursor returned as next by the previous page",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.SearchResult"
-                            }
+                            "$ref": "#/definitions/domain.NewsPage"
                         }
                     },
                     "400": {
@@ -509,96 +739,2156 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "domain.Author": {
-            "type": "object",
-            "properties": {
-                "bio": {
-                    "type": "string"
-                },
-                "createdAt": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "imageUrl": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "updatedAt": {
-                    "type": "string"
-                }
-            }
-        },
-        "domain.News": {
-            "type": "object",
-            "properties": {
-                "authorID": {
-                    "type": "string"
-                },
-                "content": {
-                    "type": "string"
-                },
-                "createdAt": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "imageUrl": {
-                    "type": "string"
-                },
-                "tags": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
+            },
+            "post": {
+                "description": "Create a new news article with the provided details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "Create a new news article",
+                "parameters": [
+                    {
+                        "description": "News article details",
+                        "name": "news",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.News"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.News"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
-                },
-                "title": {
-                    "type": "string"
-                },
-                "updatedAt": {
-                    "type": "string"
-                }
-            }
-        },
-        "domain.SearchResult": {
-            "type": "object",
-            "properties": {
-                "content": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "score": {
-                    "type": "number"
-                },
-                "title": {
-                    "type": "string"
-                },
-                "type": {
-                    "$ref": "#/definitions/domain.SearchResultType"
                 }
             }
         },
-        "domain.SearchResultType": {
-            "type": "string",
-            "enum": [
-                "news",
-                "author"
-            ],
-            "x-enum-varnames": [
-                "NewsResultType",
-                "AuthorResultType"
+        "/api/news/{id}": {
+            "get": {
+                "description": "Get a news article's details by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "Get a news article by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "News ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.News"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update an existing news article's details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "Update a news article",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "News ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated news article details",
+                        "name": "news",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.News"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.News"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a news article by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "Delete a news article",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "News ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/news/{id}/related": {
+            "get": {
+                "description": "Get the news articles most similar to an article by title, content and tags",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "Get related news articles",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "News ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 5,
+                        "description": "Number of related articles (max 20)",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Only articles by the same author",
+                        "name": "sameAuthor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Only articles sharing a tag with the article",
+                        "name": "sameTags",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.News"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/saved-searches": {
+            "get": {
+                "description": "Get a page of saved searches, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "saved-searches"
+                ],
+                "summary": "List saved searches",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of saved searches per page (max 100)",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor returned as next by the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SavedSearchPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Save a news search to be alerted about the articles matching it as they are created or updated",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "saved-searches"
+                ],
+                "summary": "Create a saved search",
+                "parameters": [
+                    {
+                        "description": "Saved search details",
+                        "name": "savedSearch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.SavedSearch"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SavedSearch"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/saved-searches/{id}": {
+            "get": {
+                "description": "Get a saved search's details by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "saved-searches"
+                ],
+                "summary": "Get a saved search by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Saved search ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SavedSearch"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace a saved search, alerting about the articles matching it from then on",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "saved-searches"
+                ],
+                "summary": "Update a saved search",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Saved search ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated saved search details",
+                        "name": "savedSearch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.SavedSearch"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SavedSearch"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a saved search by its ID, keeping the alerts it raised",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "saved-searches"
+                ],
+                "summary": "Delete a saved search",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Saved search ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/search": {
+            "get": {
+                "description": "Search news content with boosted results for specified author",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Search news with author boosting",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search query: free text, quoted exact phrases, -exclusions, qualifiers (title, content, tag, author, name, bio) and OR",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Author name to boost results for, same as boost=name:\u003cusername\u003e",
+                        "name": "username",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated preferred authors as id:\u003cid\u003e^\u003cweight\u003e or name:\u003cname\u003e^\u003cweight\u003e, weight defaulting to 2",
+                        "name": "boost",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset of the first result to return",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of results to return (max 100)",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated result types to search, e.g. news,author (default all)",
+                        "name": "types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated tags to filter news by",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "default": "any",
+                        "description": "Whether news need any or all of the tags",
+                        "name": "tagMatch",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated author ids, matching authors and their news",
+                        "name": "authorId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated months of creation as YYYY-MM, matching news from any of them",
+                        "name": "months",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only news created at or after this RFC 3339 time or YYYY-MM-DD date",
+                        "name": "createdFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only news created at or before this RFC 3339 time or YYYY-MM-DD date",
+                        "name": "createdTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only news updated at or after this RFC 3339 time or YYYY-MM-DD date",
+                        "name": "updatedFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only news updated at or before this RFC 3339 time or YYYY-MM-DD date",
+                        "name": "updatedTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Include type, tag, author and month facet counts",
+                        "name": "facets",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Edit distance tolerated per term: AUTO, AUTO:low,high, 0, 1 or 2 (default exact matching)",
+                        "name": "fuzziness",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Leading characters that must match exactly in fuzzy mode",
+                        "name": "prefixLength",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Terms each query term expands to in fuzzy mode",
+                        "name": "maxExpansions",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "score",
+                            "minmax",
+                            "rrf"
+                        ],
+                        "type": "string",
+                        "default": "score",
+                        "description": "How author and news hits are merged",
+                        "name": "merge",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Per-type score weights, e.g. news:2,author:0.5",
+                        "name": "weights",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Time limit for the whole search, shared by every result type, e.g. 500ms",
+                        "name": "timeout",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Fail the request when any result type fails or times out",
+                        "name": "strict",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Search for the spelling correction when the query has no hits",
+                        "name": "autocorrect",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 150,
+                        "description": "Length in characters of content highlight fragments (20 to 1000)",
+                        "name": "fragmentSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 3,
+                        "description": "Content highlight fragments per result (max 10), 0 highlighting the whole content",
+                        "name": "fragments",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "relevant",
+                            "fresh"
+                        ],
+                        "type": "string",
+                        "default": "relevant",
+                        "description": "Ranking profile, fresh favouring recent news",
+                        "name": "ranking",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "gauss",
+                            "exp"
+                        ],
+                        "type": "string",
+                        "description": "Decay news scores by age of creation, overriding the ranking profile",
+                        "name": "decay",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "30d",
+                        "description": "Age past decayOffset at which news score decayFactor times as much, e.g. 30d or 12h",
+                        "name": "decayScale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "0s",
+                        "description": "Age up to which news keep their full score, e.g. 1d",
+                        "name": "decayOffset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "default": 0.5,
+                        "description": "Score factor at decayScale past decayOffset, between 0 and 1",
+                        "name": "decayFactor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Add a score breakdown to every result: field matches, author boost, recency decay and merging",
+                        "name": "explain",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "en",
+                            "tr"
+                        ],
+                        "type": "string",
+                        "description": "Only results written in this language, their text searched with its analysis (default every language)",
+                        "name": "lang",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/suggest": {
+            "get": {
+                "description": "Suggest author names, news titles and tags starting with the typed prefix",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Suggest completions while typing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Typed prefix",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 8,
+                        "description": "Number of suggestions to return (max 20)",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated result types to suggest, e.g. news,author (default all)",
+                        "name": "types",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Suggestion"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/synonyms": {
+            "get": {
+                "description": "Get the synonym sets in effect and the version they belong to",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "synonyms"
+                ],
+                "summary": "List synonym sets",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SynonymVersion"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a group of equivalent search terms, saving a new synonym version",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "synonyms"
+                ],
+                "summary": "Create a synonym set",
+                "parameters": [
+                    {
+                        "description": "Synonym set, the id generated when empty",
+                        "name": "synonymSet",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.SynonymSet"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SynonymSet"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/synonyms/versions": {
+            "get": {
+                "description": "Get a page of saved synonym versions, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "synonyms"
+                ],
+                "summary": "List synonym versions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of versions per page (max 100)",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor returned as next by the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SynonymVersionPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/synonyms/versions/{version}/rollback": {
+            "post": {
+                "description": "Save the synonym sets of an earlier version as the newest version and apply them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "synonyms"
+                ],
+                "summary": "Roll back synonyms to a version",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Synonym version to roll back to",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SynonymVersion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/synonyms/{id}": {
+            "get": {
+                "description": "Get a synonym set in effect by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "synonyms"
+                ],
+                "summary": "Get a synonym set by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Synonym set ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SynonymSet"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace the terms of a synonym set, saving a new synonym version",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "synonyms"
+                ],
+                "summary": "Update a synonym set",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Synonym set ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated synonym set",
+                        "name": "synonymSet",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.SynonymSet"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SynonymSet"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a synonym set by its ID, saving a new synonym version",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "synonyms"
+                ],
+                "summary": "Delete a synonym set",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Synonym set ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "domain.Alert": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "event": {
+                    "$ref": "#/definitions/domain.AlertEvent"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "newsId": {
+                    "type": "string"
+                },
+                "newsTitle": {
+                    "type": "string"
+                },
+                "savedSearchId": {
+                    "type": "string"
+                },
+                "savedSearchName": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.AlertEvent": {
+            "type": "string",
+            "enum": [
+                "created",
+                "updated"
+            ],
+            "x-enum-varnames": [
+                "NewsCreatedEvent",
+                "NewsUpdatedEvent"
+            ]
+        },
+        "domain.AlertPage": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Alert"
+                    }
+                },
+                "next": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Author": {
+            "type": "object",
+            "properties": {
+                "bio": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "imageUrl": {
+                    "type": "string"
+                },
+                "language": {
+                    "$ref": "#/definitions/domain.Language"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.AuthorPage": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Author"
+                    }
+                },
+                "next": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ClickEvent": {
+            "type": "object",
+            "properties": {
+                "normalizedQuery": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "query": {
+                    "type": "string"
+                },
+                "resultId": {
+                    "type": "string"
+                },
+                "searchId": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/domain.SearchResultType"
+                }
+            }
+        },
+        "domain.ClickThroughReport": {
+            "type": "object",
+            "properties": {
+                "clickedSearches": {
+                    "type": "integer"
+                },
+                "clicks": {
+                    "type": "integer"
+                },
+                "queries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.QueryClickThrough"
+                    }
+                },
+                "rate": {
+                    "type": "number"
+                },
+                "searches": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.DateRange": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ExplanationNode": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ExplanationNode"
+                    }
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "domain.FacetBucket": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.FieldMatch": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "term": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.HighlightFragment": {
+            "type": "object",
+            "properties": {
+                "matches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.HighlightSpan"
+                    }
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.HighlightSpan": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "type": "integer"
+                },
+                "start": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.KindStatus": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "state": {
+                    "$ref": "#/definitions/domain.SearchState"
+                }
+            }
+        },
+        "domain.Language": {
+            "type": "string",
+            "enum": [
+                "en",
+                "tr",
+                "en"
+            ],
+            "x-enum-varnames": [
+                "English",
+                "Turkish",
+                "DefaultLanguage"
+            ]
+        },
+        "domain.MergeStrategy": {
+            "type": "string",
+            "enum": [
+                "score",
+                "minmax",
+                "rrf"
+            ],
+            "x-enum-varnames": [
+                "MergeByScore",
+                "MergeByMinMax",
+                "MergeByRRF"
+            ]
+        },
+        "domain.News": {
+            "type": "object",
+            "properties": {
+                "authorID": {
+                    "type": "string"
+                },
+                "authorImageUrl": {
+                    "type": "string"
+                },
+                "authorName": {
+                    "type": "string"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "imageUrl": {
+                    "type": "string"
+                },
+                "language": {
+                    "$ref": "#/definitions/domain.Language"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.NewsPage": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.News"
+                    }
+                },
+                "next": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.QueryClickThrough": {
+            "type": "object",
+            "properties": {
+                "clickedSearches": {
+                    "type": "integer"
+                },
+                "query": {
+                    "type": "string"
+                },
+                "rate": {
+                    "type": "number"
+                },
+                "searches": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.QueryCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "query": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ResultAuthor": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "imageUrl": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.SavedSearch": {
+            "type": "object",
+            "properties": {
+                "authorIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdRange": {
+                    "$ref": "#/definitions/domain.DateRange"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "language": {
+                    "$ref": "#/definitions/domain.Language"
+                },
+                "months": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "query": {
+                    "type": "string"
+                },
+                "tagMatch": {
+                    "$ref": "#/definitions/domain.TagMatch"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "updatedRange": {
+                    "$ref": "#/definitions/domain.DateRange"
+                }
+            }
+        },
+        "domain.SavedSearchPage": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.SavedSearch"
+                    }
+                },
+                "next": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ScoreExplanation": {
+            "type": "object",
+            "properties": {
+                "authorBoost": {
+                    "type": "number"
+                },
+                "decay": {
+                    "type": "number"
+                },
+                "details": {
+                    "$ref": "#/definitions/domain.ExplanationNode"
+                },
+                "matches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.FieldMatch"
+                    }
+                },
+                "merge": {
+                    "$ref": "#/definitions/domain.MergeStrategy"
+                },
+                "normalized": {
+                    "type": "number"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "domain.SearchResponse": {
+            "type": "object",
+            "properties": {
+                "correctedQuery": {
+                    "description": "CorrectedQuery is set when the results are those of DidYouMean\nbecause the original query had no hits.",
+                    "type": "string"
+                },
+                "didYouMean": {
+                    "description": "DidYouMean is a spelling correction of the query drawn from the\nindexed vocabulary, empty when the query looks right.",
+                    "type": "string"
+                },
+                "facets": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/domain.FacetBucket"
+                        }
+                    }
+                },
+                "from": {
+                    "type": "integer"
+                },
+                "partial": {
+                    "type": "boolean"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.SearchResult"
+                    }
+                },
+                "searchId": {
+                    "description": "SearchID identifies the search in analytics, such as when recording a\nclick on one of its results.",
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/domain.KindStatus"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "totals": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "unresolvedAuthors": {
+                    "description": "UnresolvedAuthors lists the author names, boosted or in author\nqualifiers of the query, that match no author exactly and therefore\nboost or match nothing.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "domain.SearchResult": {
+            "type": "object",
+            "properties": {
+                "articleCount": {
+                    "type": "integer"
+                },
+                "author": {
+                    "$ref": "#/definitions/domain.ResultAuthor"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "explanation": {
+                    "$ref": "#/definitions/domain.ScoreExplanation"
+                },
+                "highlights": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/domain.HighlightFragment"
+                        }
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "imageUrl": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/domain.SearchResultType"
+                }
+            }
+        },
+        "domain.SearchResultType": {
+            "type": "string",
+            "enum": [
+                "news",
+                "author",
+                "tag"
+            ],
+            "x-enum-varnames": [
+                "NewsResultType",
+                "AuthorResultType",
+                "TagResultType"
+            ]
+        },
+        "domain.SearchState": {
+            "type": "string",
+            "enum": [
+                "ok",
+                "failed",
+                "timed_out"
+            ],
+            "x-enum-varnames": [
+                "SearchStateOK",
+                "SearchStateFailed",
+                "SearchStateTimedOut"
+            ]
+        },
+        "domain.Suggestion": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/domain.SearchResultType"
+                }
+            }
+        },
+        "domain.SynonymSet": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "terms": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "domain.SynonymVersion": {
+            "type": "object",
+            "properties": {
+                "change": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "sets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.SynonymSet"
+                    }
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.SynonymVersionPage": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.SynonymVersion"
+                    }
+                },
+                "next": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.TagMatch": {
+            "type": "string",
+            "enum": [
+                "any",
+                "all"
+            ],
+            "x-enum-varnames": [
+                "TagMatchAny",
+                "TagMatchAll"
             ]
         }
     }
@@ -5,6 +5,9 @@ import "os"
 type Config struct {
 	ElasticsearchURL string
 	ServerPort       string
+	// AlertWebhookURL receives the alerts raised by saved searches, none
+	// are pushed when it is empty.
+	AlertWebhookURL string
 }
 
 func New() *Config {
@@ -21,5 +24,6 @@ func New() *Config {
 	return &Config{
 		ElasticsearchURL: esURL,
 		ServerPort:       port,
+		AlertWebhookURL:  os.Getenv("ALERT_WEBHOOK_URL"),
 	}
 }
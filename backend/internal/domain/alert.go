@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrSavedSearchNameRequired     = errors.New("saved search name is required")
+	ErrSavedSearchCriteriaRequired = errors.New("saved search needs a query, tags or authors")
+)
+
+// SavedSearch is a search kept to be alerted about news matching it as they
+// are created or updated. Its fields narrow news as in SearchFilter, with
+// CreatedRange and UpdatedRange standing for its CreatedAt and UpdatedAt.
+type SavedSearch struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	Query        string    `json:"query,omitempty"`
+	Tags         []string  `json:"tags,omitempty"`
+	TagMatch     TagMatch  `json:"tagMatch,omitempty"`
+	AuthorIDs    []string  `json:"authorIds,omitempty"`
+	Months       []string  `json:"months,omitempty"`
+	CreatedRange DateRange `json:"createdRange"`
+	UpdatedRange DateRange `json:"updatedRange"`
+	Language     Language  `json:"language,omitempty"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+func (s *SavedSearch) Validate() error {
+	if s.Name == "" {
+		return ErrSavedSearchNameRequired
+	}
+	if s.Query == "" && len(s.Tags) == 0 && len(s.AuthorIDs) == 0 {
+		return ErrSavedSearchCriteriaRequired
+	}
+	if s.TagMatch != "" && s.TagMatch != TagMatchAny && s.TagMatch != TagMatchAll {
+		return ErrInvalidTagMatch
+	}
+	for _, month := range s.Months {
+		if _, err := time.Parse(MonthLayout, month); err != nil {
+			return ErrInvalidMonth
+		}
+	}
+	if err := s.CreatedRange.Validate(); err != nil {
+		return err
+	}
+	if err := s.UpdatedRange.Validate(); err != nil {
+		return err
+	}
+	return s.Language.Validate()
+}
+
+// SearchFilter returns the filter searching news as the saved search does.
+func (s *SavedSearch) SearchFilter() SearchFilter {
+	return SearchFilter{
+		Query:     s.Query,
+		Tags:      s.Tags,
+		TagMatch:  s.TagMatch,
+		AuthorIDs: s.AuthorIDs,
+		Months:    s.Months,
+		CreatedAt: s.CreatedRange,
+		UpdatedAt: s.UpdatedRange,
+		Language:  s.Language,
+		Types:     []SearchResultType{NewsResultType},
+	}
+}
+
+// SavedSearchPage is one page of a saved search listing. Next is empty on
+// the last page.
+type SavedSearchPage struct {
+	Items []SavedSearch `json:"items"`
+	Next  string        `json:"next,omitempty"`
+}
+
+// AlertEvent is the change to an article that raised an alert.
+type AlertEvent string
+
+const (
+	NewsCreatedEvent AlertEvent = "created"
+	NewsUpdatedEvent AlertEvent = "updated"
+)
+
+// Alert reports that an article matched a saved search when it was created
+// or updated. A saved search alerts about an article once, Event telling
+// which change first matched it.
+type Alert struct {
+	ID              string     `json:"id"`
+	SavedSearchID   string     `json:"savedSearchId"`
+	SavedSearchName string     `json:"savedSearchName"`
+	NewsID          string     `json:"newsId"`
+	NewsTitle       string     `json:"newsTitle"`
+	Event           AlertEvent `json:"event"`
+	CreatedAt       time.Time  `json:"createdAt"`
+}
+
+// AlertFilter selects one page of alerts, newest first, only those of one
+// saved search when SavedSearchID is set.
+type AlertFilter struct {
+	ListFilter
+	SavedSearchID string
+}
+
+// AlertPage is one page of alerts. Next is empty on the last page.
+type AlertPage struct {
+	Items []Alert `json:"items"`
+	Next  string  `json:"next,omitempty"`
+}
+
+type SavedSearchRepository interface {
+	Create(search *SavedSearch) error
+	GetByID(id string) (*SavedSearch, error)
+	Update(search *SavedSearch) error
+	Delete(id string) error
+	List(filter ListFilter) (*SavedSearchPage, error)
+}
+
+type SavedSearchService interface {
+	Create(search *SavedSearch) error
+	GetByID(id string) (*SavedSearch, error)
+	Update(search *SavedSearch) error
+	Delete(id string) error
+	List(filter ListFilter) (*SavedSearchPage, error)
+}
+
+type AlertRepository interface {
+	// Match raises an alert for every saved search the stored article
+	// matches that has not alerted about it before, returning the alerts
+	// raised.
+	Match(news *News, event AlertEvent) ([]Alert, error)
+	List(filter AlertFilter) (*AlertPage, error)
+}
+
+type AlertService interface {
+	List(filter AlertFilter) (*AlertPage, error)
+}
+
+// AlertNotifier pushes raised alerts to a destination outside the store.
+type AlertNotifier interface {
+	Notify(alerts []Alert) error
+}
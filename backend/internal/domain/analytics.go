@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultReportSize = 10
+	MaxReportSize     = 100
+)
+
+var (
+	ErrClickSearchRequired  = errors.New("click search id is required")
+	ErrClickResultRequired  = errors.New("click result id and type are required")
+	ErrInvalidClickPosition = errors.New("click position must not be negative")
+	ErrInvalidReportSize    = errors.New("report size must be between 1 and 100")
+)
+
+// SearchEventFilters are the options a search narrowed or ranked its
+// results with.
+type SearchEventFilters struct {
+	Types     []SearchResultType `json:"types,omitempty"`
+	Tags      []string           `json:"tags,omitempty"`
+	AuthorIDs []string           `json:"authorIds,omitempty"`
+	Months    []string           `json:"months,omitempty"`
+	Boosts    int                `json:"boosts,omitempty"`
+	Ranking   RankingProfile     `json:"ranking,omitempty"`
+	Language  Language           `json:"language,omitempty"`
+	Merge     MergeStrategy      `json:"merge,omitempty"`
+	From      int                `json:"from"`
+	Size      int                `json:"size"`
+}
+
+// SearchEvent records one search. NormalizedQuery is the query lowercased
+// with runs of white space collapsed, under which reports count it. Error is
+// set when the search failed, in which case it has no results.
+type SearchEvent struct {
+	SearchID        string                     `json:"searchId"`
+	Query           string                     `json:"query"`
+	NormalizedQuery string                     `json:"normalizedQuery"`
+	Filters         SearchEventFilters         `json:"filters"`
+	Total           int64                      `json:"total"`
+	Totals          map[SearchResultType]int64 `json:"totals,omitempty"`
+	Partial         bool                       `json:"partial"`
+	CorrectedQuery  string                     `json:"correctedQuery,omitempty"`
+	LatencyMillis   int64                      `json:"latencyMs"`
+	Error           string                     `json:"error,omitempty"`
+	Timestamp       time.Time                  `json:"timestamp"`
+}
+
+// NormalizeQuery returns the form of a query that reports group by.
+func NormalizeQuery(query string) string {
+	return strings.ToLower(strings.Join(strings.Fields(query), " "))
+}
+
+// ClickEvent records a click on a result of a search, at Position counted
+// from zero across pages. The query is copied from the search.
+type ClickEvent struct {
+	SearchID        string           `json:"searchId"`
+	ResultID        string           `json:"resultId"`
+	ResultType      SearchResultType `json:"type"`
+	Position        int              `json:"position"`
+	Query           string           `json:"query,omitempty"`
+	NormalizedQuery string           `json:"normalizedQuery,omitempty"`
+	Timestamp       time.Time        `json:"timestamp"`
+}
+
+func (c *ClickEvent) Validate() error {
+	if c.SearchID == "" {
+		return ErrClickSearchRequired
+	}
+	if c.ResultID == "" || c.ResultType == "" {
+		return ErrClickResultRequired
+	}
+	if c.Position < 0 {
+		return ErrInvalidClickPosition
+	}
+	return nil
+}
+
+// ReportFilter bounds a report to the events in Range, listing at most Size
+// queries.
+type ReportFilter struct {
+	Range DateRange
+	Size  int
+}
+
+func (f *ReportFilter) Validate() error {
+	if f.Size < 1 || f.Size > MaxReportSize {
+		return ErrInvalidReportSize
+	}
+	return f.Range.Validate()
+}
+
+// QueryCount is the number of searches for a normalized query.
+type QueryCount struct {
+	Query string `json:"query"`
+	Count int64  `json:"count"`
+}
+
+// ClickThrough is the share of searches followed by at least one click.
+type ClickThrough struct {
+	Searches        int64   `json:"searches"`
+	ClickedSearches int64   `json:"clickedSearches"`
+	Rate            float64 `json:"rate"`
+}
+
+// QueryClickThrough is the click-through of the searches for one query.
+type QueryClickThrough struct {
+	Query string `json:"query"`
+	ClickThrough
+}
+
+// ClickThroughReport is the overall click-through and total clicks, with
+// the click-through of the most searched queries. ClickedSearches counts
+// distinct searches, and may be approximate for large numbers of them.
+type ClickThroughReport struct {
+	ClickThrough
+	Clicks  int64               `json:"clicks"`
+	Queries []QueryClickThrough `json:"queries"`
+}
+
+type AnalyticsRepository interface {
+	RecordSearch(event *SearchEvent) error
+	GetSearch(searchID string) (*SearchEvent, error)
+	RecordClick(click *ClickEvent) error
+	TopQueries(filter ReportFilter) ([]QueryCount, error)
+	ZeroResultQueries(filter ReportFilter) ([]QueryCount, error)
+	ClickThrough(filter ReportFilter) (*ClickThroughReport, error)
+}
+
+type AnalyticsService interface {
+	RecordClick(click *ClickEvent) error
+	TopQueries(filter ReportFilter) ([]QueryCount, error)
+	ZeroResultQueries(filter ReportFilter) ([]QueryCount, error)
+	ClickThrough(filter ReportFilter) (*ClickThroughReport, error)
+}
@@ -9,11 +9,13 @@ var (
 	ErrAuthorNameRequired = errors.New("author name is required")
 )
 
+// Author writes news. Language is detected from the bio when not given.
 type Author struct {
 	ID        string    `json:"id"`
 	Name      string    `json:"name"`
 	Bio       string    `json:"bio,omitempty"`
 	ImageURL  string    `json:"imageUrl,omitempty"`
+	Language  Language  `json:"language,omitempty"`
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
 }
@@ -22,7 +24,13 @@ func (a *Author) Validate() error {
 	if a.Name == "" {
 		return ErrAuthorNameRequired
 	}
-	return nil
+	return a.Language.Validate()
+}
+
+// AuthorPage is one page of an author listing. Next is empty on the last page.
+type AuthorPage struct {
+	Items []Author `json:"items"`
+	Next  string   `json:"next,omitempty"`
 }
 
 type AuthorRepository interface {
@@ -30,7 +38,7 @@ type AuthorRepository interface {
 	GetByID(id string) (*Author, error)
 	Update(author *Author) error
 	Delete(id string) error
-	List() ([]Author, error)
+	List(filter ListFilter) (*AuthorPage, error)
 }
 
 type AuthorService interface {
@@ -38,5 +46,5 @@ type AuthorService interface {
 	GetByID(id string) (*Author, error)
 	Update(author *Author) error
 	Delete(id string) error
-	List() ([]Author, error)
+	List(filter ListFilter) (*AuthorPage, error)
 }
@@ -0,0 +1,16 @@
+package domain
+
+import "errors"
+
+// Errors reported by repositories for failures of the underlying store.
+var (
+	// ErrNotFound is returned when a document, or the index holding it, does
+	// not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrUnavailable is returned when the store is overloaded or cannot be
+	// reached, and the request may succeed when retried.
+	ErrUnavailable = errors.New("search backend unavailable")
+	// ErrConflict is returned when a concurrent change was saved first, and
+	// the request may succeed when retried on the new state.
+	ErrConflict = errors.New("conflicting change")
+)
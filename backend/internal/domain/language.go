@@ -0,0 +1,34 @@
+package domain
+
+import "errors"
+
+var ErrUnsupportedLanguage = errors.New("language must be en or tr")
+
+// Language is the ISO 639-1 code of the language news and authors are
+// written in. Every supported language has its own analyzed subfield of the
+// text fields, named after the code.
+type Language string
+
+const (
+	English Language = "en"
+	Turkish Language = "tr"
+)
+
+// DefaultLanguage is assumed when a text gives no sign of its language.
+const DefaultLanguage = English
+
+// Languages lists the supported languages.
+var Languages = []Language{English, Turkish}
+
+// Validate accepts a supported language, or none.
+func (l Language) Validate() error {
+	if l == "" {
+		return nil
+	}
+	for _, language := range Languages {
+		if l == language {
+			return nil
+		}
+	}
+	return ErrUnsupportedLanguage
+}
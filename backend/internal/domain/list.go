@@ -0,0 +1,27 @@
+package domain
+
+import "errors"
+
+const (
+	DefaultListSize = 20
+	MaxListSize     = 100
+)
+
+var (
+	ErrInvalidListSize = errors.New("list size must be between 1 and 100")
+	ErrInvalidCursor   = errors.New("list cursor is invalid")
+)
+
+// ListFilter selects one page of a listing. Cursor is the opaque Next token
+// returned with the previous page, or empty for the first page.
+type ListFilter struct {
+	Size   int
+	Cursor string
+}
+
+func (f *ListFilter) Validate() error {
+	if f.Size < 1 || f.Size > MaxListSize {
+		return ErrInvalidListSize
+	}
+	return nil
+}
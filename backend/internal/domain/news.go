@@ -9,17 +9,27 @@ var (
 	ErrNewsTitleRequired   = errors.New("news title is required")
 	ErrNewsContentRequired = errors.New("news content is required")
 	ErrNewsAuthorRequired  = errors.New("news author is required")
+	ErrNewsAuthorNotFound  = errors.New("news author does not exist")
+	ErrInvalidRelatedSize  = errors.New("related news size must be between 1 and 20")
 )
 
+// News is an article. AuthorName and AuthorImageURL are copies of the
+// author's details kept up to date by the repository, so that news can be
+// searched by author name and shown with a byline without looking the
+// author up. Language is detected from the title and content when not
+// given.
 type News struct {
-	ID        string    `json:"id"`
-	Title     string    `json:"title"`
-	Content   string    `json:"content"`
-	AuthorID  string    `json:"authorID"`
-	Tags      []string  `json:"tags,omitempty"`
-	ImageURL  string    `json:"imageUrl,omitempty"`
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	ID             string    `json:"id"`
+	Title          string    `json:"title"`
+	Content        string    `json:"content"`
+	AuthorID       string    `json:"authorID"`
+	AuthorName     string    `json:"authorName,omitempty"`
+	AuthorImageURL string    `json:"authorImageUrl,omitempty"`
+	Tags           []string  `json:"tags,omitempty"`
+	ImageURL       string    `json:"imageUrl,omitempty"`
+	Language       Language  `json:"language,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
 }
 
 func (n *News) Validate() error {
@@ -32,6 +42,33 @@ func (n *News) Validate() error {
 	if n.AuthorID == "" {
 		return ErrNewsAuthorRequired
 	}
+	return n.Language.Validate()
+}
+
+// NewsPage is one page of a news listing. Next is empty on the last page.
+type NewsPage struct {
+	Items []News `json:"items"`
+	Next  string `json:"next,omitempty"`
+}
+
+const (
+	DefaultRelatedSize = 5
+	MaxRelatedSize     = 20
+)
+
+// RelatedFilter selects the news related to an article. SameAuthor keeps
+// only news by the article's author and SameTags only news sharing at least
+// one of its tags.
+type RelatedFilter struct {
+	Size       int
+	SameAuthor bool
+	SameTags   bool
+}
+
+func (f *RelatedFilter) Validate() error {
+	if f.Size < 1 || f.Size > MaxRelatedSize {
+		return ErrInvalidRelatedSize
+	}
 	return nil
 }
 
@@ -40,7 +77,8 @@ type NewsRepository interface {
 	GetByID(id string) (*News, error)
 	Update(news *News) error
 	Delete(id string) error
-	List() ([]News, error)
+	List(filter ListFilter) (*NewsPage, error)
+	Related(id string, filter RelatedFilter) ([]News, error)
 }
 
 type NewsService interface {
@@ -48,5 +86,6 @@ type NewsService interface {
 	GetByID(id string) (*News, error)
 	Update(news *News) error
 	Delete(id string) error
-	List() ([]News, error)
+	List(filter ListFilter) (*NewsPage, error)
+	Related(id string, filter RelatedFilter) ([]News, error)
 }
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrInvalidQuery wraps every syntax error of the user query language.
+var ErrInvalidQuery = errors.New("invalid query")
+
+// ClauseType tells what a QueryClause matches.
+type ClauseType string
+
+const (
+	// TermClause matches a free text word.
+	TermClause ClauseType = "term"
+	// PhraseClause matches a quoted phrase word for word.
+	PhraseClause ClauseType = "phrase"
+	// FieldClause matches a value against a qualifier such as tag:cloud.
+	FieldClause ClauseType = "field"
+	// OrClause matches when any of its alternatives does.
+	OrClause ClauseType = "or"
+)
+
+// Qualifiers of the user query language.
+const (
+	TitleQualifier   = "title"
+	ContentQualifier = "content"
+	TagQualifier     = "tag"
+	AuthorQualifier  = "author"
+	NameQualifier    = "name"
+	BioQualifier     = "bio"
+)
+
+// QueryQualifiers is the set of qualifiers a query may use.
+var QueryQualifiers = map[string]bool{
+	TitleQualifier:   true,
+	ContentQualifier: true,
+	TagQualifier:     true,
+	AuthorQualifier:  true,
+	NameQualifier:    true,
+	BioQualifier:     true,
+}
+
+// QueryClause is one element of a parsed query. Every top level clause
+// must match, apart from free text terms which are matched together and
+// rank documents containing more of them higher.
+type QueryClause struct {
+	Type ClauseType
+	// Text is the word, phrase or qualifier value.
+	Text string
+	// Field is the qualifier of a FieldClause.
+	Field string
+	// Phrase marks a quoted qualifier value.
+	Phrase bool
+	// Negated excludes documents matching the clause.
+	Negated bool
+	// Any holds the alternatives of an OrClause.
+	Any []QueryClause
+}
+
+// ParsedQuery is the structured form of a query typed by a user.
+type ParsedQuery struct {
+	Clauses []QueryClause
+}
+
+// IsPlain reports whether the query consists of free text terms only.
+func (q *ParsedQuery) IsPlain() bool {
+	for _, clause := range q.Clauses {
+		if clause.Type != TermClause || clause.Negated {
+			return false
+		}
+	}
+	return true
+}
+
+// FreeText returns the free text terms that are not negated, joined by spaces.
+func (q *ParsedQuery) FreeText() string {
+	terms := make([]string, 0, len(q.Clauses))
+	for _, clause := range q.Clauses {
+		if clause.Type == TermClause && !clause.Negated {
+			terms = append(terms, clause.Text)
+		}
+	}
+	return strings.Join(terms, " ")
+}
@@ -1,31 +1,526 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultSearchSize = 10
+	MaxSearchSize     = 100
+	// MaxSearchWindow mirrors Elasticsearch's default index.max_result_window.
+	MaxSearchWindow = 10000
+)
+
+var (
+	ErrInvalidSearchFrom     = errors.New("search from must not be negative")
+	ErrInvalidSearchSize     = errors.New("search size must be between 1 and 100")
+	ErrSearchWindowTooDeep   = errors.New("search from + size must not exceed 10000")
+	ErrUnknownMergeStrategy  = errors.New("unknown merge strategy")
+	ErrUnknownResultType     = errors.New("unknown result type")
+	ErrInvalidWeight         = errors.New("result type weights must be positive")
+	ErrInvalidSearchTimeout  = errors.New("search timeout must not be negative")
+	ErrInvalidMonth          = errors.New("month must be formatted as YYYY-MM")
+	ErrInvalidTagMatch       = errors.New("tag match must be any or all")
+	ErrInvalidDateRange      = errors.New("date range must not end before it starts")
+	ErrInvalidAuthorBoost    = errors.New("author boosts need either an id or a name and a positive weight")
+	ErrInvalidFuzziness      = errors.New("fuzziness must be AUTO, AUTO:low,high, 0, 1 or 2 with a non-negative prefix length and max expansions")
+	ErrSuggestPrefixRequired = errors.New("suggest prefix is required")
+	ErrInvalidSuggestSize    = errors.New("suggest size must be between 1 and 20")
+	ErrInvalidFragmentSize   = errors.New("highlight fragment size must be between 20 and 1000")
+	ErrInvalidFragments      = errors.New("highlight fragments must be between 0 and 10")
+	ErrUnknownRankingProfile = errors.New("ranking profile must be relevant or fresh")
+	ErrInvalidDecay          = errors.New("decay must be gauss or exp with a positive scale, a non-negative offset and a decay between 0 and 1 exclusive")
+	// ErrSearchIncomplete is returned in strict mode when any kind of the
+	// combined search did not complete successfully.
+	ErrSearchIncomplete = errors.New("search did not complete for every result type")
+)
 
 type SearchResultType string
 
 const (
 	NewsResultType   SearchResultType = "news"
 	AuthorResultType SearchResultType = "author"
+	// TagResultType is only used for suggestions, tags are not searchable
+	// on their own.
+	TagResultType SearchResultType = "tag"
+)
+
+// MergeStrategy decides how hits from different indices, whose raw scores
+// are not comparable, are interleaved into one result list.
+type MergeStrategy string
+
+const (
+	// MergeByScore sorts by the raw Elasticsearch score.
+	MergeByScore MergeStrategy = "score"
+	// MergeByMinMax scales each type's scores to [0, 1] before sorting.
+	MergeByMinMax MergeStrategy = "minmax"
+	// MergeByRRF ranks by reciprocal rank fusion, ignoring raw scores.
+	MergeByRRF MergeStrategy = "rrf"
+)
+
+// SearchState is the outcome of the search over a single result type.
+type SearchState string
+
+const (
+	SearchStateOK       SearchState = "ok"
+	SearchStateFailed   SearchState = "failed"
+	SearchStateTimedOut SearchState = "timed_out"
+)
+
+type KindStatus struct {
+	State SearchState `json:"state"`
+	Error string      `json:"error,omitempty"`
+}
+
+// Facet names used as keys of SearchResponse.Facets.
+const (
+	TypeFacet   = "type"
+	TagFacet    = "tag"
+	AuthorFacet = "author"
+	MonthFacet  = "month"
+)
+
+// MonthLayout is the format of month facet keys and month filters.
+const MonthLayout = "2006-01"
+
+// TagMatch decides whether hits need any or all of the filtered tags.
+type TagMatch string
+
+const (
+	TagMatchAny TagMatch = "any"
+	TagMatchAll TagMatch = "all"
 )
 
+// DateRange bounds a timestamp, From inclusive and To exclusive. A nil
+// bound leaves that side open.
+type DateRange struct {
+	From *time.Time `json:"from,omitempty"`
+	To   *time.Time `json:"to,omitempty"`
+}
+
+func (r DateRange) IsZero() bool {
+	return r.From == nil && r.To == nil
+}
+
+func (r DateRange) Validate() error {
+	if r.From != nil && r.To != nil && r.To.Before(*r.From) {
+		return ErrInvalidDateRange
+	}
+	return nil
+}
+
+// DefaultAuthorBoost is the weight of an author boost that gives none.
+const DefaultAuthorBoost = 2.0
+
+// AuthorBoost ranks news by one author higher. The author is given either
+// by ID or by Name, which must match the author's name exactly apart from
+// letter case.
+type AuthorBoost struct {
+	ID     string  `json:"id,omitempty"`
+	Name   string  `json:"name,omitempty"`
+	Weight float64 `json:"weight"`
+}
+
+func (b AuthorBoost) Validate() error {
+	if (b.ID == "") == (b.Name == "") || b.Weight <= 0 {
+		return ErrInvalidAuthorBoost
+	}
+	return nil
+}
+
+// Fuzziness makes the query tolerate typos. Distance is the allowed edit
+// distance as accepted by Elasticsearch; an empty distance disables fuzzy
+// matching. PrefixLength leading characters must match exactly and at most
+// MaxExpansions terms are tried per query term, Elasticsearch's default when
+// zero.
+type Fuzziness struct {
+	Distance      string
+	PrefixLength  int
+	MaxExpansions int
+}
+
+func (f Fuzziness) Enabled() bool {
+	return f.Distance != ""
+}
+
+func (f Fuzziness) Validate() error {
+	if f.PrefixLength < 0 || f.MaxExpansions < 0 {
+		return ErrInvalidFuzziness
+	}
+	switch f.Distance {
+	case "", "AUTO", "0", "1", "2":
+		return nil
+	}
+	// AUTO:low,high with 0 <= low <= high
+	bounds, ok := strings.CutPrefix(f.Distance, "AUTO:")
+	if !ok {
+		return ErrInvalidFuzziness
+	}
+	rawLow, rawHigh, ok := strings.Cut(bounds, ",")
+	if !ok {
+		return ErrInvalidFuzziness
+	}
+	low, errLow := strconv.Atoi(rawLow)
+	high, errHigh := strconv.Atoi(rawHigh)
+	if errLow != nil || errHigh != nil || low < 0 || high < low {
+		return ErrInvalidFuzziness
+	}
+	return nil
+}
+
+// FacetBucket is one value of a facet and the number of hits carrying it.
+// Label is a display name when the key is an identifier.
+type FacetBucket struct {
+	Key   string `json:"key"`
+	Label string `json:"label,omitempty"`
+	Count int64  `json:"count"`
+}
+
+const (
+	DefaultFragmentSize = 150
+	MinFragmentSize     = 20
+	MaxFragmentSize     = 1000
+	DefaultFragments    = 3
+	MaxFragments        = 10
+)
+
+// HighlightSpan is a match within a fragment, from Start inclusive to End
+// exclusive, counted in Unicode code points.
+type HighlightSpan struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// HighlightFragment is a plain text excerpt of a field and the spans of it
+// that match the query. The text is not HTML escaped.
+type HighlightFragment struct {
+	Text    string          `json:"text"`
+	Matches []HighlightSpan `json:"matches"`
+}
+
+// ResultAuthor is the byline of a news result.
+type ResultAuthor struct {
+	ID       string `json:"id"`
+	Name     string `json:"name,omitempty"`
+	ImageURL string `json:"imageUrl,omitempty"`
+}
+
+// ScoreExplanation breaks down the score of a result. Elasticsearch scored
+// the hit Score from the Matches of query terms in fields, author
+// qualifiers included, and the matches of author boosts adding AuthorBoost,
+// multiplied by the recency Decay when ranking by recency. The Merge strategy then turned Score into Normalized, which times
+// the result type's Weight is the result's score. Match scores are those of
+// each term before the query combines them, and Details is the full
+// explanation Elasticsearch gave.
+type ScoreExplanation struct {
+	Score       float64          `json:"score"`
+	Matches     []FieldMatch     `json:"matches"`
+	AuthorBoost float64          `json:"authorBoost"`
+	Decay       *float64         `json:"decay,omitempty"`
+	Merge       MergeStrategy    `json:"merge"`
+	Normalized  float64          `json:"normalized"`
+	Weight      float64          `json:"weight"`
+	Details     *ExplanationNode `json:"details,omitempty"`
+}
+
+// FieldMatch is the score of a query term found in a field of a hit.
+type FieldMatch struct {
+	Field string  `json:"field"`
+	Term  string  `json:"term"`
+	Score float64 `json:"score"`
+}
+
+// ExplanationNode is one step of Elasticsearch's score computation, whose
+// value derives from its details as described.
+type ExplanationNode struct {
+	Value       float64           `json:"value"`
+	Description string            `json:"description"`
+	Details     []ExplanationNode `json:"details,omitempty"`
+}
+
+// SearchResult is a single hit. Title and Content are the plain field values;
+// Highlights holds the matching fragments of each, keyed by "title" and
+// "content", and omits fields without a match. News results also carry their
+// author, creation time and tags, and author results the number of news they
+// wrote. Explanation is only set when the search asked to explain scores.
 type SearchResult struct {
-	ID      string           `json:"id"`
-	Title   string           `json:"title"`
-	Content string           `json:"content"`
-	Score   float64          `json:"score"`
-	Type    SearchResultType `json:"type"`
+	ID           string                         `json:"id"`
+	Title        string                         `json:"title"`
+	Content      string                         `json:"content"`
+	Highlights   map[string][]HighlightFragment `json:"highlights,omitempty"`
+	Score        float64                        `json:"score"`
+	Type         SearchResultType               `json:"type"`
+	ImageURL     string                         `json:"imageUrl,omitempty"`
+	Author       *ResultAuthor                  `json:"author,omitempty"`
+	CreatedAt    *time.Time                     `json:"createdAt,omitempty"`
+	Tags         []string                       `json:"tags,omitempty"`
+	ArticleCount *int64                         `json:"articleCount,omitempty"`
+	Explanation  *ScoreExplanation              `json:"explanation,omitempty"`
+}
+
+// DecayFunction is the shape of the curve lowering scores with age.
+type DecayFunction string
+
+const (
+	// DecayGauss keeps recent news close to their full score and drops
+	// sharply around Scale.
+	DecayGauss DecayFunction = "gauss"
+	// DecayExp drops fastest right after Offset and then ever more slowly.
+	DecayExp DecayFunction = "exp"
+)
+
+const (
+	DefaultDecayScale = 30 * 24 * time.Hour
+	DefaultDecay      = 0.5
+)
+
+// Recency multiplies the score of news by a decay of their age. News no
+// older than Offset keep their score, and those Offset+Scale old score Decay
+// times it. An empty Function leaves scores untouched.
+type Recency struct {
+	Function DecayFunction
+	Scale    time.Duration
+	Offset   time.Duration
+	Decay    float64
+}
+
+func (r Recency) Enabled() bool {
+	return r.Function != ""
 }
 
+func (r Recency) Validate() error {
+	if !r.Enabled() {
+		return nil
+	}
+	if r.Function != DecayGauss && r.Function != DecayExp {
+		return ErrInvalidDecay
+	}
+	if r.Scale <= 0 || r.Offset < 0 || r.Decay <= 0 || r.Decay >= 1 {
+		return ErrInvalidDecay
+	}
+	return nil
+}
+
+// RankingProfile names a preset way of ranking news.
+type RankingProfile string
+
+const (
+	// RankingRelevant ranks by relevance alone.
+	RankingRelevant RankingProfile = "relevant"
+	// RankingFresh halves the score of news a month older than a day.
+	RankingFresh RankingProfile = "fresh"
+)
+
+// RankingProfiles holds the recency of every ranking profile.
+var RankingProfiles = map[RankingProfile]Recency{
+	RankingRelevant: {},
+	RankingFresh: {
+		Function: DecayGauss,
+		Scale:    DefaultDecayScale,
+		Offset:   24 * time.Hour,
+		Decay:    DefaultDecay,
+	},
+}
+
+// Highlight result fields, the keys of SearchResult.Highlights.
+const (
+	TitleHighlight   = "title"
+	ContentHighlight = "content"
+)
+
 type SearchFilter struct {
-	Query    string
-	Username string
+	Query string
+	// Parsed is the structured form of Query, set by the repository before
+	// the kinds build their queries.
+	Parsed *ParsedQuery
+	// Boosts ranks news by the preferred authors higher.
+	Boosts []AuthorBoost
+	From   int
+	Size   int
+	// Types restricts the search to these result types, all when empty.
+	Types []SearchResultType
+	// Tags, AuthorIDs and Months narrow the search to hits carrying any of
+	// the given facet values. Months are formatted as MonthLayout.
+	Tags      []string
+	AuthorIDs []string
+	Months    []string
+	// TagMatch requires all of Tags instead of any when set to TagMatchAll.
+	TagMatch TagMatch
+	// CreatedAt and UpdatedAt narrow news to those timestamps.
+	CreatedAt DateRange
+	UpdatedAt DateRange
+	// Facets requests facet counts in the response.
+	Facets bool
+	// Merge selects the merge strategy, MergeByScore when empty.
+	Merge MergeStrategy
+	// Weights multiplies the merged score of each result type, 1 when absent.
+	Weights map[SearchResultType]float64
+	// Timeout bounds the whole search, unbounded when zero. Every result
+	// type runs in the same request and shares it: a type whose shards run
+	// out of time returns the hits found so far, and every type is timed out
+	// once the request itself exceeds it.
+	Timeout time.Duration
+	// Strict turns the failure or timeout of any result type into
+	// ErrSearchIncomplete instead of a partial response.
+	Strict bool
+	// Fuzziness matches query terms with typos, scored below exact matches.
+	Fuzziness Fuzziness
+	// AutoCorrect re-runs the search with the spelling correction in
+	// DidYouMean when the query itself has no hits.
+	AutoCorrect bool
+	// FragmentSize is the length in characters of content highlight
+	// fragments, and Fragments their maximum number per result, zero
+	// highlighting the whole content as a single fragment. Titles are always
+	// highlighted whole.
+	FragmentSize int
+	Fragments    int
+	// Ranking selects a ranking profile, RankingRelevant when empty.
+	// Recency, when enabled, replaces the profile's decay.
+	Ranking RankingProfile
+	Recency Recency
+	// Explain adds a breakdown of its score to every result.
+	Explain bool
+	// Language keeps the results written in that language and searches
+	// their text fields with its analysis, stemming and folding the query
+	// accordingly. With no language every language's analysis is tried.
+	Language Language
+}
+
+// NewsRecency returns the decay applied to news scores, the request's own
+// or else that of its ranking profile.
+func (f *SearchFilter) NewsRecency() Recency {
+	if f.Recency.Enabled() {
+		return f.Recency
+	}
+	return RankingProfiles[f.Ranking]
+}
+
+func (f *SearchFilter) Validate() error {
+	if f.From < 0 {
+		return ErrInvalidSearchFrom
+	}
+	if f.Size < 1 || f.Size > MaxSearchSize {
+		return ErrInvalidSearchSize
+	}
+	if f.From+f.Size > MaxSearchWindow {
+		return ErrSearchWindowTooDeep
+	}
+	if f.Timeout < 0 {
+		return ErrInvalidSearchTimeout
+	}
+	if f.FragmentSize < MinFragmentSize || f.FragmentSize > MaxFragmentSize {
+		return ErrInvalidFragmentSize
+	}
+	if f.Fragments < 0 || f.Fragments > MaxFragments {
+		return ErrInvalidFragments
+	}
+	if err := f.Fuzziness.Validate(); err != nil {
+		return err
+	}
+	if _, ok := RankingProfiles[f.Ranking]; f.Ranking != "" && !ok {
+		return ErrUnknownRankingProfile
+	}
+	if err := f.Recency.Validate(); err != nil {
+		return err
+	}
+	if err := f.Language.Validate(); err != nil {
+		return err
+	}
+	if f.TagMatch != "" && f.TagMatch != TagMatchAny && f.TagMatch != TagMatchAll {
+		return ErrInvalidTagMatch
+	}
+	if err := f.CreatedAt.Validate(); err != nil {
+		return err
+	}
+	if err := f.UpdatedAt.Validate(); err != nil {
+		return err
+	}
+	for _, boost := range f.Boosts {
+		if err := boost.Validate(); err != nil {
+			return err
+		}
+	}
+	for _, month := range f.Months {
+		if _, err := time.Parse(MonthLayout, month); err != nil {
+			return ErrInvalidMonth
+		}
+	}
+	for _, weight := range f.Weights {
+		if weight <= 0 {
+			return ErrInvalidWeight
+		}
+	}
+	return nil
+}
+
+// SearchResponse is a single page of the merged search results together with
+// the total number of hits and the search status per result type. Partial is
+// set when any result type failed or timed out, in which case its hits and
+// total are missing or incomplete.
+type SearchResponse struct {
+	// SearchID identifies the search in analytics, such as when recording a
+	// click on one of its results.
+	SearchID string                          `json:"searchId,omitempty"`
+	Results  []SearchResult                  `json:"results"`
+	Total    int64                           `json:"total"`
+	Totals   map[SearchResultType]int64      `json:"totals"`
+	Status   map[SearchResultType]KindStatus `json:"status"`
+	Partial  bool                            `json:"partial"`
+	Facets   map[string][]FacetBucket        `json:"facets,omitempty"`
+	// DidYouMean is a spelling correction of the query drawn from the
+	// indexed vocabulary, empty when the query looks right.
+	DidYouMean string `json:"didYouMean,omitempty"`
+	// CorrectedQuery is set when the results are those of DidYouMean
+	// because the original query had no hits.
+	CorrectedQuery string `json:"correctedQuery,omitempty"`
+	// UnresolvedAuthors lists the author names, boosted or in author
+	// qualifiers of the query, that match no author exactly and therefore
+	// boost or match nothing.
+	UnresolvedAuthors []string `json:"unresolvedAuthors,omitempty"`
+	From              int      `json:"from"`
+	Size              int      `json:"size"`
+}
+
+const (
+	DefaultSuggestSize = 8
+	MaxSuggestSize     = 20
+)
+
+// Suggestion is one type-ahead completion. ID identifies the suggested
+// document and is empty for tags.
+type Suggestion struct {
+	Text string           `json:"text"`
+	Type SearchResultType `json:"type"`
+	ID   string           `json:"id,omitempty"`
+}
+
+type SuggestFilter struct {
+	Prefix string
+	Size   int
+	// Types restricts suggestions to these result types, all when empty.
+	Types []SearchResultType
+}
+
+func (f *SuggestFilter) Validate() error {
+	if f.Prefix == "" {
+		return ErrSuggestPrefixRequired
+	}
+	if f.Size < 1 || f.Size > MaxSuggestSize {
+		return ErrInvalidSuggestSize
+	}
+	return nil
 }
 
 type SearchService interface {
-	Search(ctx context.Context, filter SearchFilter) ([]SearchResult, error)
+	Search(ctx context.Context, filter SearchFilter) (*SearchResponse, error)
+	Suggest(ctx context.Context, filter SuggestFilter) ([]Suggestion, error)
 }
 
 type SearchRepository interface {
-	Search(ctx context.Context, filter SearchFilter) ([]SearchResult, error)
+	Search(ctx context.Context, filter SearchFilter) (*SearchResponse, error)
+	Suggest(ctx context.Context, filter SuggestFilter) ([]Suggestion, error)
 }
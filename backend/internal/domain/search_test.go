@@ -0,0 +1,48 @@
+package domain
+
+import "testing"
+
+func TestFuzzinessValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		fuzziness Fuzziness
+		wantErr   bool
+	}{
+		{name: "disabled", fuzziness: Fuzziness{}},
+		{name: "disabled with prefix length", fuzziness: Fuzziness{PrefixLength: 2, MaxExpansions: 10}},
+		{name: "auto", fuzziness: Fuzziness{Distance: "AUTO"}},
+		{name: "zero", fuzziness: Fuzziness{Distance: "0"}},
+		{name: "one", fuzziness: Fuzziness{Distance: "1"}},
+		{name: "two", fuzziness: Fuzziness{Distance: "2", PrefixLength: 1, MaxExpansions: 50}},
+		{name: "auto with bounds", fuzziness: Fuzziness{Distance: "AUTO:3,6"}},
+		{name: "auto with equal bounds", fuzziness: Fuzziness{Distance: "AUTO:4,4"}},
+		{name: "auto with zero low bound", fuzziness: Fuzziness{Distance: "AUTO:0,5"}},
+		{name: "distance above two", fuzziness: Fuzziness{Distance: "3"}, wantErr: true},
+		{name: "negative distance", fuzziness: Fuzziness{Distance: "-1"}, wantErr: true},
+		{name: "lower case auto", fuzziness: Fuzziness{Distance: "auto"}, wantErr: true},
+		{name: "unknown word", fuzziness: Fuzziness{Distance: "FUZZY"}, wantErr: true},
+		{name: "auto without bounds", fuzziness: Fuzziness{Distance: "AUTO:"}, wantErr: true},
+		{name: "auto with one bound", fuzziness: Fuzziness{Distance: "AUTO:3"}, wantErr: true},
+		{name: "auto with empty high bound", fuzziness: Fuzziness{Distance: "AUTO:3,"}, wantErr: true},
+		{name: "auto with three bounds", fuzziness: Fuzziness{Distance: "AUTO:3,6,9"}, wantErr: true},
+		{name: "auto with reversed bounds", fuzziness: Fuzziness{Distance: "AUTO:6,3"}, wantErr: true},
+		{name: "auto with negative bound", fuzziness: Fuzziness{Distance: "AUTO:-1,3"}, wantErr: true},
+		{name: "auto with spaced bounds", fuzziness: Fuzziness{Distance: "AUTO: 3,6"}, wantErr: true},
+		{name: "auto with non-numeric bounds", fuzziness: Fuzziness{Distance: "AUTO:low,high"}, wantErr: true},
+		{name: "negative prefix length", fuzziness: Fuzziness{Distance: "AUTO", PrefixLength: -1}, wantErr: true},
+		{name: "negative max expansions", fuzziness: Fuzziness{Distance: "1", MaxExpansions: -1}, wantErr: true},
+		{name: "negative prefix length when disabled", fuzziness: Fuzziness{PrefixLength: -1}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.fuzziness.Validate()
+			if tt.wantErr && err != ErrInvalidFuzziness {
+				t.Errorf("Validate() error = %v, want %v", err, ErrInvalidFuzziness)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("Validate() error = %v, want nil", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrSynonymTermsRequired = errors.New("synonym set needs at least two terms")
+	ErrInvalidSynonymTerm   = errors.New("synonym terms must not be empty or contain commas, => or line breaks")
+	ErrSynonymSetExists     = errors.New("synonym set already exists")
+)
+
+// SynonymSet is a group of equivalent terms, such as k8s and kubernetes. A
+// search for any of them also matches the others. Terms may be several
+// words long.
+type SynonymSet struct {
+	ID    string   `json:"id"`
+	Terms []string `json:"terms"`
+}
+
+func (s *SynonymSet) Validate() error {
+	if len(s.Terms) < 2 {
+		return ErrSynonymTermsRequired
+	}
+	for i, term := range s.Terms {
+		term = strings.TrimSpace(term)
+		if term == "" || strings.ContainsAny(term, ",\r\n") || strings.Contains(term, "=>") {
+			return ErrInvalidSynonymTerm
+		}
+		s.Terms[i] = term
+	}
+	return nil
+}
+
+// SynonymVersion is the complete list of synonym sets after one change.
+// Every change saves a new version numbered one above the last, so a bad
+// change is undone by rolling back to an earlier version, which saves its
+// sets again as the newest. Change describes what produced the version.
+type SynonymVersion struct {
+	Version   int          `json:"version"`
+	Sets      []SynonymSet `json:"sets"`
+	Change    string       `json:"change"`
+	CreatedAt time.Time    `json:"createdAt"`
+}
+
+// Set returns the synonym set with the given ID, or nil.
+func (v *SynonymVersion) Set(id string) *SynonymSet {
+	for i := range v.Sets {
+		if v.Sets[i].ID == id {
+			return &v.Sets[i]
+		}
+	}
+	return nil
+}
+
+// SynonymVersionPage is one page of synonym versions, newest first. Next is
+// empty on the last page.
+type SynonymVersionPage struct {
+	Items []SynonymVersion `json:"items"`
+	Next  string           `json:"next,omitempty"`
+}
+
+type SynonymRepository interface {
+	// Latest returns the newest version, version 0 without sets when none
+	// was saved yet.
+	Latest() (*SynonymVersion, error)
+	GetVersion(version int) (*SynonymVersion, error)
+	ListVersions(filter ListFilter) (*SynonymVersionPage, error)
+	// Save stores a new version and applies its sets to search, failing
+	// with ErrConflict when a version with the same number exists.
+	Save(version *SynonymVersion) error
+}
+
+type SynonymService interface {
+	List() (*SynonymVersion, error)
+	GetByID(id string) (*SynonymSet, error)
+	Create(set *SynonymSet) error
+	Update(set *SynonymSet) error
+	Delete(id string) error
+	ListVersions(filter ListFilter) (*SynonymVersionPage, error)
+	Rollback(version int) (*SynonymVersion, error)
+}
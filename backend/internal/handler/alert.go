@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/oSoloTurk/multiple-kind-search/internal/domain"
+)
+
+type AlertHandler struct {
+	service domain.AlertService
+}
+
+func NewAlertHandler(service domain.AlertService) *AlertHandler {
+	return &AlertHandler{service: service}
+}
+
+// List godoc
+// @Summary List alerts
+// @Description Get a page of the alerts raised by articles matching saved searches, newest first
+// @Tags alerts
+// @Accept json
+// @Produce json
+// @Param savedSearchId query string false "Only alerts raised by this saved search"
+// @Param size query int false "Number of alerts per page (max 100)" default(20)
+// @Param cursor query string false "Opaque cursor returned as next by the previous page"
+// @Success 200 {object} domain.AlertPage
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /api/alerts [get]
+func (h *AlertHandler) List(c *fiber.Ctx) error {
+	page, err := h.service.List(domain.AlertFilter{
+		ListFilter: domain.ListFilter{
+			Size:   c.QueryInt("size", domain.DefaultListSize),
+			Cursor: c.Query("cursor"),
+		},
+		SavedSearchID: c.Query("savedSearchId"),
+	})
+	if err != nil {
+		if err == domain.ErrInvalidListSize || err == domain.ErrInvalidCursor {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(storeErrorStatus(err)).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(page)
+}
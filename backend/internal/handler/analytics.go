@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/oSoloTurk/multiple-kind-search/internal/domain"
+)
+
+type AnalyticsHandler struct {
+	service domain.AnalyticsService
+}
+
+func NewAnalyticsHandler(service domain.AnalyticsService) *AnalyticsHandler {
+	return &AnalyticsHandler{service: service}
+}
+
+// RecordClick godoc
+// @Summary Record a click on a search result
+// @Description Record that a result of a search was opened, the search given by the searchId of its response
+// @Tags analytics
+// @Accept json
+// @Produce json
+// @Param click body domain.ClickEvent true "Clicked result and its search"
+// @Success 201 {object} domain.ClickEvent
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /api/analytics/clicks [post]
+func (h *AnalyticsHandler) RecordClick(c *fiber.Ctx) error {
+	var click domain.ClickEvent
+	if err := c.BodyParser(&click); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if err := h.service.RecordClick(&click); err != nil {
+		if err == domain.ErrClickSearchRequired || err == domain.ErrClickResultRequired || err == domain.ErrInvalidClickPosition {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		if errors.Is(err, domain.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Search not found",
+			})
+		}
+		return c.Status(storeErrorStatus(err)).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(click)
+}
+
+// TopQueries godoc
+// @Summary Report the most frequent queries
+// @Description Count searches per query, lowercased with white space collapsed, most frequent first
+// @Tags analytics
+// @Accept json
+// @Produce json
+// @Param from query string false "Only searches at or after this RFC 3339 time or YYYY-MM-DD date"
+// @Param to query string false "Only searches at or before this RFC 3339 time or YYYY-MM-DD date"
+// @Param size query int false "Number of queries to report (max 100)" default(10)
+// @Success 200 {array} domain.QueryCount
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /api/analytics/top-queries [get]
+func (h *AnalyticsHandler) TopQueries(c *fiber.Ctx) error {
+	filter, err := parseReportFilter(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	counts, err := h.service.TopQueries(filter)
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	return c.JSON(counts)
+}
+
+// ZeroResultQueries godoc
+// @Summary Report the queries without results
+// @Description Count searches whose query completed without any result per query, including those autocorrect rescued, most frequent first
+// @Tags analytics
+// @Accept json
+// @Produce json
+// @Param from query string false "Only searches at or after this RFC 3339 time or YYYY-MM-DD date"
+// @Param to query string false "Only searches at or before this RFC 3339 time or YYYY-MM-DD date"
+// @Param size query int false "Number of queries to report (max 100)" default(10)
+// @Success 200 {array} domain.QueryCount
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /api/analytics/zero-results [get]
+func (h *AnalyticsHandler) ZeroResultQueries(c *fiber.Ctx) error {
+	filter, err := parseReportFilter(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	counts, err := h.service.ZeroResultQueries(filter)
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	return c.JSON(counts)
+}
+
+// ClickThrough godoc
+// @Summary Report the click-through rate
+// @Description Get the share of searches with at least one clicked result, overall and for the most searched queries
+// @Tags analytics
+// @Accept json
+// @Produce json
+// @Param from query string false "Only events at or after this RFC 3339 time or YYYY-MM-DD date"
+// @Param to query string false "Only events at or before this RFC 3339 time or YYYY-MM-DD date"
+// @Param size query int false "Number of queries to report (max 100)" default(10)
+// @Success 200 {object} domain.ClickThroughReport
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /api/analytics/click-through [get]
+func (h *AnalyticsHandler) ClickThrough(c *fiber.Ctx) error {
+	filter, err := parseReportFilter(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	report, err := h.service.ClickThrough(filter)
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	return c.JSON(report)
+}
+
+// parseReportFilter reads the time range and size of a report.
+func parseReportFilter(c *fiber.Ctx) (domain.ReportFilter, error) {
+	dateRange, err := parseDateRange(c.Query("from"), c.Query("to"))
+	if err != nil {
+		return domain.ReportFilter{}, err
+	}
+	return domain.ReportFilter{
+		Range: dateRange,
+		Size:  c.QueryInt("size", domain.DefaultReportSize),
+	}, nil
+}
+
+func reportError(c *fiber.Ctx, err error) error {
+	if err == domain.ErrInvalidReportSize || err == domain.ErrInvalidDateRange {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.Status(storeErrorStatus(err)).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"errors"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/oSoloTurk/multiple-kind-search/internal/domain"
 )
@@ -23,6 +25,7 @@ func NewAuthorHandler(service domain.AuthorService) *AuthorHandler {
 // @Success 201 {object} domain.Author
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]string
 // @Router /api/authors [post]
 func (h *AuthorHandler) Create(c *fiber.Ctx) error {
 	var author domain.Author
@@ -33,12 +36,12 @@ func (h *AuthorHandler) Create(c *fiber.Ctx) error {
 	}
 
 	if err := h.service.Create(&author); err != nil {
-		if err == domain.ErrAuthorNameRequired {
+		if err == domain.ErrAuthorNameRequired || err == domain.ErrUnsupportedLanguage {
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 				"error": err.Error(),
 			})
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		return c.Status(storeErrorStatus(err)).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
@@ -55,15 +58,22 @@ func (h *AuthorHandler) Create(c *fiber.Ctx) error {
 // @Param id path string true "Author ID"
 // @Success 200 {object} domain.Author
 // @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]string
 // @Router /api/authors/{id} [get]
 func (h *AuthorHandler) GetByID(c *fiber.Ctx) error {
 	id := c.Params("id")
 	author, err := h.service.GetByID(id)
-	if err != nil {
+	if errors.Is(err, domain.ErrNotFound) {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 			"error": "Author not found",
 		})
 	}
+	if err != nil {
+		return c.Status(storeErrorStatus(err)).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
 
 	return c.JSON(author)
 }
@@ -78,7 +88,9 @@ func (h *AuthorHandler) GetByID(c *fiber.Ctx) error {
 // @Param author body domain.Author true "Updated author details"
 // @Success 200 {object} domain.Author
 // @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]string
 // @Router /api/authors/{id} [put]
 func (h *AuthorHandler) Update(c *fiber.Ctx) error {
 	id := c.Params("id")
@@ -91,12 +103,12 @@ func (h *AuthorHandler) Update(c *fiber.Ctx) error {
 
 	author.ID = id
 	if err := h.service.Update(&author); err != nil {
-		if err == domain.ErrAuthorNameRequired {
+		if err == domain.ErrAuthorNameRequired || err == domain.ErrUnsupportedLanguage {
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 				"error": err.Error(),
 			})
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		return c.Status(storeErrorStatus(err)).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
@@ -112,12 +124,14 @@ func (h *AuthorHandler) Update(c *fiber.Ctx) error {
 // @Produce json
 // @Param id path string true "Author ID"
 // @Success 204 "No Content"
+// @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]string
 // @Router /api/authors/{id} [delete]
 func (h *AuthorHandler) Delete(c *fiber.Ctx) error {
 	id := c.Params("id")
 	if err := h.service.Delete(id); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		return c.Status(storeErrorStatus(err)).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
@@ -126,23 +140,35 @@ func (h *AuthorHandler) Delete(c *fiber.Ctx) error {
 }
 
 // List godoc
-// @Summary List all authors
-// @Description Get a list of all authors
+// @Summary List authors
+// @Description Get a page of authors, newest first
 // @Tags authors
 // @Accept json
 // @Produce json
-// @Success 200 {array} domain.Author
+// @Param size query int false "Number of authors per page (max 100)" default(20)
+// @Param cursor query string false "Opaque cursor returned as next by the previous page"
+// @Success 200 {object} domain.AuthorPage
+// @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]string
 // @Router /api/authors [get]
 func (h *AuthorHandler) List(c *fiber.Ctx) error {
-	authors, err := h.service.List()
+	page, err := h.service.List(domain.ListFilter{
+		Size:   c.QueryInt("size", domain.DefaultListSize),
+		Cursor: c.Query("cursor"),
+	})
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		if err == domain.ErrInvalidListSize || err == domain.ErrInvalidCursor {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(storeErrorStatus(err)).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
 
-	return c.JSON(authors)
+	return c.JSON(page)
 }
 
 // ... rest of the handler methods remain similar but use service instead of repo
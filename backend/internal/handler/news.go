@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"errors"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/oSoloTurk/multiple-kind-search/internal/domain"
 )
@@ -23,6 +25,7 @@ func NewNewsHandler(service domain.NewsService) *NewsHandler {
 // @Success 201 {object} domain.News
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]string
 // @Router /api/news [post]
 func (h *NewsHandler) Create(c *fiber.Ctx) error {
 	var news domain.News
@@ -33,12 +36,12 @@ func (h *NewsHandler) Create(c *fiber.Ctx) error {
 	}
 
 	if err := h.service.Create(&news); err != nil {
-		if err == domain.ErrNewsTitleRequired || err == domain.ErrNewsContentRequired || err == domain.ErrNewsAuthorRequired {
+		if err == domain.ErrNewsTitleRequired || err == domain.ErrNewsContentRequired || err == domain.ErrNewsAuthorRequired || err == domain.ErrNewsAuthorNotFound || err == domain.ErrUnsupportedLanguage {
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 				"error": err.Error(),
 			})
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		return c.Status(storeErrorStatus(err)).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
@@ -55,15 +58,22 @@ func (h *NewsHandler) Create(c *fiber.Ctx) error {
 // @Param id path string true "News ID"
 // @Success 200 {object} domain.News
 // @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]string
 // @Router /api/news/{id} [get]
 func (h *NewsHandler) GetByID(c *fiber.Ctx) error {
 	id := c.Params("id")
 	news, err := h.service.GetByID(id)
-	if err != nil {
+	if errors.Is(err, domain.ErrNotFound) {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 			"error": "News not found",
 		})
 	}
+	if err != nil {
+		return c.Status(storeErrorStatus(err)).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
 
 	return c.JSON(news)
 }
@@ -78,7 +88,9 @@ func (h *NewsHandler) GetByID(c *fiber.Ctx) error {
 // @Param news body domain.News true "Updated news article details"
 // @Success 200 {object} domain.News
 // @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]string
 // @Router /api/news/{id} [put]
 func (h *NewsHandler) Update(c *fiber.Ctx) error {
 	id := c.Params("id")
@@ -91,12 +103,12 @@ func (h *NewsHandler) Update(c *fiber.Ctx) error {
 
 	news.ID = id
 	if err := h.service.Update(&news); err != nil {
-		if err == domain.ErrNewsTitleRequired || err == domain.ErrNewsContentRequired || err == domain.ErrNewsAuthorRequired {
+		if err == domain.ErrNewsTitleRequired || err == domain.ErrNewsContentRequired || err == domain.ErrNewsAuthorRequired || err == domain.ErrNewsAuthorNotFound || err == domain.ErrUnsupportedLanguage {
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 				"error": err.Error(),
 			})
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		return c.Status(storeErrorStatus(err)).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
@@ -112,12 +124,14 @@ func (h *NewsHandler) Update(c *fiber.Ctx) error {
 // @Produce json
 // @Param id path string true "News ID"
 // @Success 204 "No Content"
+// @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]string
 // @Router /api/news/{id} [delete]
 func (h *NewsHandler) Delete(c *fiber.Ctx) error {
 	id := c.Params("id")
 	if err := h.service.Delete(id); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		return c.Status(storeErrorStatus(err)).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
@@ -126,21 +140,74 @@ func (h *NewsHandler) Delete(c *fiber.Ctx) error {
 }
 
 // List godoc
-// @Summary List all news articles
-// @Description Get a list of all news articles
+// @Summary List news articles
+// @Description Get a page of news articles, newest first
 // @Tags news
 // @Accept json
 // @Produce json
-// @Success 200 {array} domain.News
+// @Param size query int false "Number of news articles per page (max 100)" default(20)
+// @Param cursor query string false "Opaque cursor returned as next by the previous page"
+// @Success 200 {object} domain.NewsPage
+// @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]string
 // @Router /api/news [get]
 func (h *NewsHandler) List(c *fiber.Ctx) error {
-	news, err := h.service.List()
+	page, err := h.service.List(domain.ListFilter{
+		Size:   c.QueryInt("size", domain.DefaultListSize),
+		Cursor: c.Query("cursor"),
+	})
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		if err == domain.ErrInvalidListSize || err == domain.ErrInvalidCursor {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(storeErrorStatus(err)).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
 
-	return c.JSON(news)
+	return c.JSON(page)
+}
+
+// Related godoc
+// @Summary Get related news articles
+// @Description Get the news articles most similar to an article by title, content and tags
+// @Tags news
+// @Accept json
+// @Produce json
+// @Param id path string true "News ID"
+// @Param size query int false "Number of related articles (max 20)" default(5)
+// @Param sameAuthor query bool false "Only articles by the same author" default(false)
+// @Param sameTags query bool false "Only articles sharing a tag with the article" default(false)
+// @Success 200 {array} domain.News
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /api/news/{id}/related [get]
+func (h *NewsHandler) Related(c *fiber.Ctx) error {
+	related, err := h.service.Related(c.Params("id"), domain.RelatedFilter{
+		Size:       c.QueryInt("size", domain.DefaultRelatedSize),
+		SameAuthor: c.QueryBool("sameAuthor"),
+		SameTags:   c.QueryBool("sameTags"),
+	})
+	if errors.Is(err, domain.ErrNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "News not found",
+		})
+	}
+	if err != nil {
+		if err == domain.ErrInvalidRelatedSize {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(storeErrorStatus(err)).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(related)
 }
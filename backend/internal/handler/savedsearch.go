@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/oSoloTurk/multiple-kind-search/internal/domain"
+)
+
+type SavedSearchHandler struct {
+	service domain.SavedSearchService
+}
+
+func NewSavedSearchHandler(service domain.SavedSearchService) *SavedSearchHandler {
+	return &SavedSearchHandler{service: service}
+}
+
+// Create godoc
+// @Summary Create a saved search
+// @Description Save a news search to be alerted about the articles matching it as they are created or updated
+// @Tags saved-searches
+// @Accept json
+// @Produce json
+// @Param savedSearch body domain.SavedSearch true "Saved search details"
+// @Success 201 {object} domain.SavedSearch
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /api/saved-searches [post]
+func (h *SavedSearchHandler) Create(c *fiber.Ctx) error {
+	var search domain.SavedSearch
+	if err := c.BodyParser(&search); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if err := h.service.Create(&search); err != nil {
+		return savedSearchError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(search)
+}
+
+// GetByID godoc
+// @Summary Get a saved search by ID
+// @Description Get a saved search's details by its ID
+// @Tags saved-searches
+// @Accept json
+// @Produce json
+// @Param id path string true "Saved search ID"
+// @Success 200 {object} domain.SavedSearch
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /api/saved-searches/{id} [get]
+func (h *SavedSearchHandler) GetByID(c *fiber.Ctx) error {
+	id := c.Params("id")
+	search, err := h.service.GetByID(id)
+	if errors.Is(err, domain.ErrNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Saved search not found",
+		})
+	}
+	if err != nil {
+		return c.Status(storeErrorStatus(err)).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(search)
+}
+
+// Update godoc
+// @Summary Update a saved search
+// @Description Replace a saved search, alerting about the articles matching it from then on
+// @Tags saved-searches
+// @Accept json
+// @Produce json
+// @Param id path string true "Saved search ID"
+// @Param savedSearch body domain.SavedSearch true "Updated saved search details"
+// @Success 200 {object} domain.SavedSearch
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /api/saved-searches/{id} [put]
+func (h *SavedSearchHandler) Update(c *fiber.Ctx) error {
+	id := c.Params("id")
+	var search domain.SavedSearch
+	if err := c.BodyParser(&search); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	search.ID = id
+	if err := h.service.Update(&search); err != nil {
+		return savedSearchError(c, err)
+	}
+
+	return c.JSON(search)
+}
+
+// Delete godoc
+// @Summary Delete a saved search
+// @Description Delete a saved search by its ID, keeping the alerts it raised
+// @Tags saved-searches
+// @Accept json
+// @Produce json
+// @Param id path string true "Saved search ID"
+// @Success 204 "No Content"
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /api/saved-searches/{id} [delete]
+func (h *SavedSearchHandler) Delete(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if err := h.service.Delete(id); err != nil {
+		return c.Status(storeErrorStatus(err)).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// List godoc
+// @Summary List saved searches
+// @Description Get a page of saved searches, newest first
+// @Tags saved-searches
+// @Accept json
+// @Produce json
+// @Param size query int false "Number of saved searches per page (max 100)" default(20)
+// @Param cursor query string false "Opaque cursor returned as next by the previous page"
+// @Success 200 {object} domain.SavedSearchPage
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /api/saved-searches [get]
+func (h *SavedSearchHandler) List(c *fiber.Ctx) error {
+	page, err := h.service.List(domain.ListFilter{
+		Size:   c.QueryInt("size", domain.DefaultListSize),
+		Cursor: c.Query("cursor"),
+	})
+	if err != nil {
+		if err == domain.ErrInvalidListSize || err == domain.ErrInvalidCursor {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(storeErrorStatus(err)).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(page)
+}
+
+// badSavedSearchErrors are the saved search errors caused by the request
+// itself.
+var badSavedSearchErrors = []error{
+	domain.ErrSavedSearchNameRequired,
+	domain.ErrSavedSearchCriteriaRequired,
+	domain.ErrInvalidTagMatch,
+	domain.ErrInvalidMonth,
+	domain.ErrInvalidDateRange,
+	domain.ErrUnsupportedLanguage,
+	domain.ErrInvalidQuery,
+}
+
+func savedSearchError(c *fiber.Ctx, err error) error {
+	for _, target := range badSavedSearchErrors {
+		if errors.Is(err, target) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+	if errors.Is(err, domain.ErrNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Saved search not found",
+		})
+	}
+	return c.Status(storeErrorStatus(err)).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
@@ -1,6 +1,12 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/oSoloTurk/multiple-kind-search/internal/domain"
 	"github.com/oSoloTurk/multiple-kind-search/internal/logger"
@@ -20,11 +26,43 @@ func NewSearchHandler(searchService domain.SearchService) *SearchHandler {
 // @Tags search
 // @Accept json
 // @Produce json
-// @Param q query string true "Search query"
-// @Param username query string true "Author username to boost results for"
-// @Success 200 {array} domain.SearchResult
+// @Param q query string true "Search query: free text, quoted exact phrases, -exclusions, qualifiers (title, content, tag, author, name, bio) and OR"
+// @Param username query string false "Author name to boost results for, same as boost=name:<username>"
+// @Param boost query string false "Comma separated preferred authors as id:<id>^<weight> or name:<name>^<weight>, weight defaulting to 2"
+// @Param from query int false "Offset of the first result to return" default(0)
+// @Param size query int false "Number of results to return (max 100)" default(10)
+// @Param types query string false "Comma separated result types to search, e.g. news,author (default all)"
+// @Param tags query string false "Comma separated tags to filter news by"
+// @Param tagMatch query string false "Whether news need any or all of the tags" Enums(any, all) default(any)
+// @Param authorId query string false "Comma separated author ids, matching authors and their news"
+// @Param months query string false "Comma separated months of creation as YYYY-MM, matching news from any of them"
+// @Param createdFrom query string false "Only news created at or after this RFC 3339 time or YYYY-MM-DD date"
+// @Param createdTo query string false "Only news created at or before this RFC 3339 time or YYYY-MM-DD date"
+// @Param updatedFrom query string false "Only news updated at or after this RFC 3339 time or YYYY-MM-DD date"
+// @Param updatedTo query string false "Only news updated at or before this RFC 3339 time or YYYY-MM-DD date"
+// @Param facets query bool false "Include type, tag, author and month facet counts" default(false)
+// @Param fuzziness query string false "Edit distance tolerated per term: AUTO, AUTO:low,high, 0, 1 or 2 (default exact matching)"
+// @Param prefixLength query int false "Leading characters that must match exactly in fuzzy mode" default(0)
+// @Param maxExpansions query int false "Terms each query term expands to in fuzzy mode" default(50)
+// @Param merge query string false "How author and news hits are merged" Enums(score, minmax, rrf) default(score)
+// @Param weights query string false "Per-type score weights, e.g. news:2,author:0.5"
+// @Param timeout query string false "Time limit for the whole search, shared by every result type, e.g. 500ms"
+// @Param strict query bool false "Fail the request when any result type fails or times out" default(false)
+// @Param autocorrect query bool false "Search for the spelling correction when the query has no hits" default(false)
+// @Param fragmentSize query int false "Length in characters of content highlight fragments (20 to 1000)" default(150)
+// @Param fragments query int false "Content highlight fragments per result (max 10), 0 highlighting the whole content" default(3)
+// @Param ranking query string false "Ranking profile, fresh favouring recent news" Enums(relevant, fresh) default(relevant)
+// @Param decay query string false "Decay news scores by age of creation, overriding the ranking profile" Enums(gauss, exp)
+// @Param decayScale query string false "Age past decayOffset at which news score decayFactor times as much, e.g. 30d or 12h" default(30d)
+// @Param decayOffset query string false "Age up to which news keep their full score, e.g. 1d" default(0s)
+// @Param decayFactor query number false "Score factor at decayScale past decayOffset, between 0 and 1" default(0.5)
+// @Param explain query bool false "Add a score breakdown to every result: field matches, author boost, recency decay and merging" default(false)
+// @Param lang query string false "Only results written in this language, their text searched with its analysis (default every language)" Enums(en, tr)
+// @Success 200 {object} domain.SearchResponse
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Failure 503 {object} map[string]string
 // @Router /api/search [get]
 func (h *SearchHandler) Search(c *fiber.Ctx) error {
 	query := c.Query("q")
@@ -35,39 +73,356 @@ func (h *SearchHandler) Search(c *fiber.Ctx) error {
 		})
 	}
 
+	boosts, err := parseBoosts(c.Query("boost"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
 	username := c.Query("username")
-	if username == "" {
-		logger.Logger.Error().Msg("Username is empty")
+	if username != "" {
+		boosts = append(boosts, domain.AuthorBoost{Name: username, Weight: domain.DefaultAuthorBoost})
+	}
+
+	from := c.QueryInt("from", 0)
+	size := c.QueryInt("size", domain.DefaultSearchSize)
+
+	weights, err := parseWeights(c.Query("weights"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	createdAt, err := parseDateRange(c.Query("createdFrom"), c.Query("createdTo"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	updatedAt, err := parseDateRange(c.Query("updatedFrom"), c.Query("updatedTo"))
+	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Username is required",
+			"error": err.Error(),
+		})
+	}
+
+	var timeout time.Duration
+	if raw := c.Query("timeout"); raw != "" {
+		timeout, err = time.ParseDuration(raw)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": fmt.Sprintf("invalid timeout %q", raw),
+			})
+		}
+	}
+
+	recency, err := parseRecency(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
 		})
 	}
 
 	logger.Logger.Info().
 		Str("query", query).
-		Str("username", username).
+		Int("boosts", len(boosts)).
+		Int("from", from).
+		Int("size", size).
 		Msg("Processing search request")
 
-	results, err := h.searchService.Search(c.UserContext(), domain.SearchFilter{
-		Query:    query,
-		Username: username,
+	response, err := h.searchService.Search(c.UserContext(), domain.SearchFilter{
+		Query:     query,
+		Boosts:    boosts,
+		From:      from,
+		Size:      size,
+		Types:     parseTypes(c.Query("types")),
+		Tags:      parseList(c.Query("tags")),
+		AuthorIDs: parseList(c.Query("authorId")),
+		Months:    parseList(c.Query("months")),
+		TagMatch:  domain.TagMatch(c.Query("tagMatch")),
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
+		Facets:    c.QueryBool("facets"),
+		Fuzziness: domain.Fuzziness{
+			Distance:      strings.ToUpper(c.Query("fuzziness")),
+			PrefixLength:  c.QueryInt("prefixLength", 0),
+			MaxExpansions: c.QueryInt("maxExpansions", 0),
+		},
+		Merge:        domain.MergeStrategy(c.Query("merge")),
+		Weights:      weights,
+		Timeout:      timeout,
+		Strict:       c.QueryBool("strict"),
+		AutoCorrect:  c.QueryBool("autocorrect"),
+		FragmentSize: c.QueryInt("fragmentSize", domain.DefaultFragmentSize),
+		Fragments:    c.QueryInt("fragments", domain.DefaultFragments),
+		Ranking:      domain.RankingProfile(c.Query("ranking")),
+		Recency:      recency,
+		Explain:      c.QueryBool("explain"),
+		Language:     domain.Language(strings.ToLower(c.Query("lang"))),
 	})
 	if err != nil {
+		if isBadSearchRequest(err) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		if errors.Is(err, domain.ErrSearchIncomplete) {
+			logger.Logger.Error().
+				Err(err).
+				Str("query", query).
+				Msg("Strict search did not complete")
+			return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		logger.Logger.Error().
 			Err(err).
 			Str("query", query).
-			Str("username", username).
+			Int("boosts", len(boosts)).
 			Msg("Failed to search news")
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		return c.Status(storeErrorStatus(err)).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
 
 	logger.Logger.Info().
 		Str("query", query).
-		Str("username", username).
-		Int("results", len(results)).
-		Msg("Search completed successfully")
+		Int("boosts", len(boosts)).
+		Int64("total", response.Total).
+		Int("results", len(response.Results)).
+		Bool("partial", response.Partial).
+		Msg("Search completed")
+
+	return c.JSON(response)
+}
+
+// Suggest godoc
+// @Summary Suggest completions while typing
+// @Description Suggest author names, news titles and tags starting with the typed prefix
+// @Tags search
+// @Accept json
+// @Produce json
+// @Param q query string true "Typed prefix"
+// @Param size query int false "Number of suggestions to return (max 20)" default(8)
+// @Param types query string false "Comma separated result types to suggest, e.g. news,author (default all)"
+// @Success 200 {array} domain.Suggestion
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /api/suggest [get]
+func (h *SearchHandler) Suggest(c *fiber.Ctx) error {
+	suggestions, err := h.searchService.Suggest(c.UserContext(), domain.SuggestFilter{
+		Prefix: strings.TrimSpace(c.Query("q")),
+		Size:   c.QueryInt("size", domain.DefaultSuggestSize),
+		Types:  parseTypes(c.Query("types")),
+	})
+	if err != nil {
+		if isBadSearchRequest(err) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		logger.Logger.Error().
+			Err(err).
+			Str("prefix", c.Query("q")).
+			Msg("Failed to suggest")
+		return c.Status(storeErrorStatus(err)).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(suggestions)
+}
+
+// badSearchRequestErrors are the search errors caused by the request itself.
+var badSearchRequestErrors = []error{
+	domain.ErrInvalidSearchFrom,
+	domain.ErrInvalidSearchSize,
+	domain.ErrSearchWindowTooDeep,
+	domain.ErrUnknownMergeStrategy,
+	domain.ErrUnknownResultType,
+	domain.ErrInvalidWeight,
+	domain.ErrInvalidSearchTimeout,
+	domain.ErrInvalidMonth,
+	domain.ErrInvalidTagMatch,
+	domain.ErrInvalidDateRange,
+	domain.ErrInvalidAuthorBoost,
+	domain.ErrInvalidFuzziness,
+	domain.ErrSuggestPrefixRequired,
+	domain.ErrInvalidSuggestSize,
+	domain.ErrInvalidQuery,
+	domain.ErrInvalidFragmentSize,
+	domain.ErrInvalidFragments,
+	domain.ErrUnknownRankingProfile,
+	domain.ErrInvalidDecay,
+	domain.ErrUnsupportedLanguage,
+}
+
+func isBadSearchRequest(err error) bool {
+	for _, target := range badSearchRequestErrors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseList parses a comma separated list, dropping empty entries.
+func parseList(raw string) []string {
+	values := make([]string, 0)
+	for _, value := range strings.Split(raw, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// parseTypes parses a comma separated list of result types.
+func parseTypes(raw string) []domain.SearchResultType {
+	types := make([]domain.SearchResultType, 0)
+	for _, resultType := range parseList(raw) {
+		types = append(types, domain.SearchResultType(resultType))
+	}
+	return types
+}
+
+// parseBoosts parses preferred authors given as "id:<id>^<weight>" or
+// "name:<name>^<weight>". A bare value is a name and the weight defaults to
+// domain.DefaultAuthorBoost.
+func parseBoosts(raw string) ([]domain.AuthorBoost, error) {
+	boosts := make([]domain.AuthorBoost, 0)
+	for _, entry := range parseList(raw) {
+		boost := domain.AuthorBoost{Weight: domain.DefaultAuthorBoost}
+
+		if author, rawWeight, ok := strings.Cut(entry, "^"); ok {
+			weight, err := strconv.ParseFloat(strings.TrimSpace(rawWeight), 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid boost %q, expected a numeric weight", entry)
+			}
+			boost.Weight = weight
+			entry = strings.TrimSpace(author)
+		}
+
+		if id, ok := strings.CutPrefix(entry, "id:"); ok {
+			boost.ID = strings.TrimSpace(id)
+		} else {
+			boost.Name = strings.TrimSpace(strings.TrimPrefix(entry, "name:"))
+		}
+		boosts = append(boosts, boost)
+	}
+	return boosts, nil
+}
+
+// parseWeights parses per-type weights given as "type:weight,type:weight".
+func parseWeights(raw string) (map[domain.SearchResultType]float64, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	weights := make(map[domain.SearchResultType]float64)
+	for _, pair := range strings.Split(raw, ",") {
+		resultType, value, ok := strings.Cut(pair, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid weight %q, expected type:weight", pair)
+		}
+		weight, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid weight %q, expected type:weight", pair)
+		}
+		weights[domain.SearchResultType(strings.TrimSpace(resultType))] = weight
+	}
+	return weights, nil
+}
+
+// parseDateRange parses inclusive from and to bounds given as RFC 3339 times
+// or dates. A date as the upper bound covers that whole day.
+func parseDateRange(rawFrom, rawTo string) (domain.DateRange, error) {
+	var dateRange domain.DateRange
+	if rawFrom != "" {
+		from, _, err := parseTime(rawFrom)
+		if err != nil {
+			return dateRange, err
+		}
+		dateRange.From = &from
+	}
+	if rawTo != "" {
+		to, dateOnly, err := parseTime(rawTo)
+		if err != nil {
+			return dateRange, err
+		}
+		// DateRange.To is exclusive and Elasticsearch dates have millisecond precision
+		if dateOnly {
+			to = to.AddDate(0, 0, 1)
+		} else {
+			to = to.Truncate(time.Millisecond).Add(time.Millisecond)
+		}
+		dateRange.To = &to
+	}
+	return dateRange, nil
+}
+
+// parseTime parses an RFC 3339 time or a YYYY-MM-DD date, reporting which
+// of the two it was.
+func parseTime(raw string) (time.Time, bool, error) {
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, false, nil
+	}
+	t, err := time.Parse(time.DateOnly, raw)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid time %q, expected RFC 3339 or YYYY-MM-DD", raw)
+	}
+	return t, true, nil
+}
+
+// parseRecency reads the decay parameters, returning a disabled recency when
+// no decay function is given.
+func parseRecency(c *fiber.Ctx) (domain.Recency, error) {
+	function := c.Query("decay")
+	if function == "" {
+		return domain.Recency{}, nil
+	}
 
-	return c.JSON(results)
+	recency := domain.Recency{
+		Function: domain.DecayFunction(strings.ToLower(function)),
+		Scale:    domain.DefaultDecayScale,
+		Decay:    domain.DefaultDecay,
+	}
+	if raw := c.Query("decayScale"); raw != "" {
+		scale, err := parseAge(raw)
+		if err != nil {
+			return recency, fmt.Errorf("invalid decay scale %q", raw)
+		}
+		recency.Scale = scale
+	}
+	if raw := c.Query("decayOffset"); raw != "" {
+		offset, err := parseAge(raw)
+		if err != nil {
+			return recency, fmt.Errorf("invalid decay offset %q", raw)
+		}
+		recency.Offset = offset
+	}
+	if raw := c.Query("decayFactor"); raw != "" {
+		decay, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return recency, fmt.Errorf("invalid decay factor %q", raw)
+		}
+		recency.Decay = decay
+	}
+	return recency, nil
+}
+
+// parseAge parses a Go duration, or a whole number of days such as 30d.
+func parseAge(raw string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(raw, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(raw)
 }
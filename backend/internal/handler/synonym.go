@@ -0,0 +1,239 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/oSoloTurk/multiple-kind-search/internal/domain"
+)
+
+type SynonymHandler struct {
+	service domain.SynonymService
+}
+
+func NewSynonymHandler(service domain.SynonymService) *SynonymHandler {
+	return &SynonymHandler{service: service}
+}
+
+// List godoc
+// @Summary List synonym sets
+// @Description Get the synonym sets in effect and the version they belong to
+// @Tags synonyms
+// @Accept json
+// @Produce json
+// @Success 200 {object} domain.SynonymVersion
+// @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /api/synonyms [get]
+func (h *SynonymHandler) List(c *fiber.Ctx) error {
+	version, err := h.service.List()
+	if err != nil {
+		return c.Status(storeErrorStatus(err)).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(version)
+}
+
+// Create godoc
+// @Summary Create a synonym set
+// @Description Create a group of equivalent search terms, saving a new synonym version
+// @Tags synonyms
+// @Accept json
+// @Produce json
+// @Param synonymSet body domain.SynonymSet true "Synonym set, the id generated when empty"
+// @Success 201 {object} domain.SynonymSet
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /api/synonyms [post]
+func (h *SynonymHandler) Create(c *fiber.Ctx) error {
+	var set domain.SynonymSet
+	if err := c.BodyParser(&set); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if err := h.service.Create(&set); err != nil {
+		if err == domain.ErrSynonymTermsRequired || err == domain.ErrInvalidSynonymTerm {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		if err == domain.ErrSynonymSetExists {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(storeErrorStatus(err)).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(set)
+}
+
+// GetByID godoc
+// @Summary Get a synonym set by ID
+// @Description Get a synonym set in effect by its ID
+// @Tags synonyms
+// @Accept json
+// @Produce json
+// @Param id path string true "Synonym set ID"
+// @Success 200 {object} domain.SynonymSet
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /api/synonyms/{id} [get]
+func (h *SynonymHandler) GetByID(c *fiber.Ctx) error {
+	set, err := h.service.GetByID(c.Params("id"))
+	if errors.Is(err, domain.ErrNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Synonym set not found",
+		})
+	}
+	if err != nil {
+		return c.Status(storeErrorStatus(err)).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(set)
+}
+
+// Update godoc
+// @Summary Update a synonym set
+// @Description Replace the terms of a synonym set, saving a new synonym version
+// @Tags synonyms
+// @Accept json
+// @Produce json
+// @Param id path string true "Synonym set ID"
+// @Param synonymSet body domain.SynonymSet true "Updated synonym set"
+// @Success 200 {object} domain.SynonymSet
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /api/synonyms/{id} [put]
+func (h *SynonymHandler) Update(c *fiber.Ctx) error {
+	var set domain.SynonymSet
+	if err := c.BodyParser(&set); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	set.ID = c.Params("id")
+	if err := h.service.Update(&set); err != nil {
+		if err == domain.ErrSynonymTermsRequired || err == domain.ErrInvalidSynonymTerm {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(storeErrorStatus(err)).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(set)
+}
+
+// Delete godoc
+// @Summary Delete a synonym set
+// @Description Delete a synonym set by its ID, saving a new synonym version
+// @Tags synonyms
+// @Accept json
+// @Produce json
+// @Param id path string true "Synonym set ID"
+// @Success 204 "No Content"
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /api/synonyms/{id} [delete]
+func (h *SynonymHandler) Delete(c *fiber.Ctx) error {
+	if err := h.service.Delete(c.Params("id")); err != nil {
+		return c.Status(storeErrorStatus(err)).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// ListVersions godoc
+// @Summary List synonym versions
+// @Description Get a page of saved synonym versions, newest first
+// @Tags synonyms
+// @Accept json
+// @Produce json
+// @Param size query int false "Number of versions per page (max 100)" default(20)
+// @Param cursor query string false "Opaque cursor returned as next by the previous page"
+// @Success 200 {object} domain.SynonymVersionPage
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /api/synonyms/versions [get]
+func (h *SynonymHandler) ListVersions(c *fiber.Ctx) error {
+	page, err := h.service.ListVersions(domain.ListFilter{
+		Size:   c.QueryInt("size", domain.DefaultListSize),
+		Cursor: c.Query("cursor"),
+	})
+	if errors.Is(err, domain.ErrNotFound) {
+		// Nothing was saved yet
+		return c.JSON(domain.SynonymVersionPage{Items: make([]domain.SynonymVersion, 0)})
+	}
+	if err != nil {
+		if err == domain.ErrInvalidListSize || err == domain.ErrInvalidCursor {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(storeErrorStatus(err)).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(page)
+}
+
+// Rollback godoc
+// @Summary Roll back synonyms to a version
+// @Description Save the synonym sets of an earlier version as the newest version and apply them
+// @Tags synonyms
+// @Accept json
+// @Produce json
+// @Param version path int true "Synonym version to roll back to"
+// @Success 200 {object} domain.SynonymVersion
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /api/synonyms/versions/{version}/rollback [post]
+func (h *SynonymHandler) Rollback(c *fiber.Ctx) error {
+	number, err := c.ParamsInt("version")
+	if err != nil || number < 1 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "version must be a positive number",
+		})
+	}
+
+	version, err := h.service.Rollback(number)
+	if errors.Is(err, domain.ErrNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Synonym version not found",
+		})
+	}
+	if err != nil {
+		return c.Status(storeErrorStatus(err)).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(version)
+}
@@ -0,0 +1,61 @@
+// Package langdetect guesses which of the supported languages a text is
+// written in. It only needs to tell English from Turkish, so it counts
+// common function words of each and words spelled with letters only Turkish
+// uses, rather than modelling the languages.
+package langdetect
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/oSoloTurk/multiple-kind-search/internal/domain"
+)
+
+// turkishLetters appear in Turkish words and never in English ones.
+const turkishLetters = "çğıöşüÇĞİÖŞÜ"
+
+var englishWords = wordSet(
+	"a", "about", "an", "and", "are", "as", "at", "be", "by", "for", "from",
+	"has", "have", "in", "is", "it", "its", "of", "on", "or", "that", "the",
+	"this", "to", "was", "were", "which", "will", "with",
+)
+
+var turkishWords = wordSet(
+	"ama", "ancak", "bir", "bu", "çok", "da", "daha", "de", "değil", "gibi",
+	"göre", "için", "ile", "kadar", "ki", "mı", "mi", "ne", "olan", "olarak",
+	"sonra", "şu", "ve", "veya", "ya",
+)
+
+func wordSet(words ...string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, word := range words {
+		set[word] = true
+	}
+	return set
+}
+
+// Detect returns the language of text, domain.DefaultLanguage when neither
+// language scores higher.
+func Detect(text string) domain.Language {
+	english, turkish := 0, 0
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+	for _, word := range words {
+		// Lowercase each way, so that I and İ both map to their own i
+		if englishWords[strings.ToLower(word)] {
+			english++
+		}
+		if turkishWords[strings.ToLowerSpecial(unicode.TurkishCase, word)] {
+			turkish++
+		}
+		if strings.ContainsAny(word, turkishLetters) {
+			turkish++
+		}
+	}
+
+	if turkish > english {
+		return domain.Turkish
+	}
+	return domain.DefaultLanguage
+}
@@ -0,0 +1,79 @@
+package langdetect
+
+import (
+	"testing"
+
+	"github.com/oSoloTurk/multiple-kind-search/internal/domain"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want domain.Language
+	}{
+		{
+			name: "english",
+			text: "The future of cloud computing is shaped by the providers that build it.",
+			want: domain.English,
+		},
+		{
+			name: "turkish",
+			text: "Bulut bilişimin geleceği, onu inşa eden sağlayıcılar tarafından şekilleniyor.",
+			want: domain.Turkish,
+		},
+		{
+			name: "turkish without turkish letters",
+			text: "Bu bir deneme ve daha sonra yine bakarız",
+			want: domain.Turkish,
+		},
+		{
+			name: "turkish in upper case",
+			text: "BU BİR DENEME VE DAHA FAZLASI İÇİN",
+			want: domain.Turkish,
+		},
+		{
+			name: "turkish suffix after an apostrophe",
+			text: "İstanbul'da bulut",
+			want: domain.Turkish,
+		},
+		{
+			name: "english mentioning a turkish name",
+			text: "Jane Smith wrote about the startups of İstanbul and the cloud.",
+			want: domain.English,
+		},
+		{
+			name: "english pronoun in upper case",
+			text: "I think it is the best of them",
+			want: domain.English,
+		},
+		{
+			name: "empty",
+			text: "",
+			want: domain.DefaultLanguage,
+		},
+		{
+			name: "single ambiguous word",
+			text: "cloud",
+			want: domain.DefaultLanguage,
+		},
+		{
+			name: "numbers and punctuation only",
+			text: "2024-03-01 12:00 !!!",
+			want: domain.DefaultLanguage,
+		},
+		{
+			name: "tie falls back to the default",
+			text: "ve and",
+			want: domain.DefaultLanguage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Detect(tt.text); got != tt.want {
+				t.Errorf("Detect(%q) = %s, want %s", tt.text, got, tt.want)
+			}
+		})
+	}
+}
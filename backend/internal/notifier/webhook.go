@@ -0,0 +1,52 @@
+// Package notifier pushes alerts raised by saved searches out of the service.
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/oSoloTurk/multiple-kind-search/internal/domain"
+)
+
+// webhookTimeout bounds one delivery, so a slow receiver cannot pile up
+// pending deliveries.
+const webhookTimeout = 10 * time.Second
+
+type webhookPayload struct {
+	Alerts []domain.Alert `json:"alerts"`
+}
+
+type webhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookNotifier returns a notifier posting the alerts raised by one
+// article change as a JSON object {"alerts": [...]} to the URL.
+func NewWebhookNotifier(url string) domain.AlertNotifier {
+	return &webhookNotifier{
+		url:    url,
+		client: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+func (n *webhookNotifier) Notify(alerts []domain.Alert) error {
+	body, err := json.Marshal(webhookPayload{Alerts: alerts})
+	if err != nil {
+		return err
+	}
+
+	res, err := n.client.Post(n.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to post alerts: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("alert webhook responded %s", res.Status)
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+// Package querylang parses the query syntax users type into the search box:
+//
+//	cloud computing        free text, ranked by how many terms match
+//	"edge computing"       exact phrase
+//	-serverless            exclude documents matching a term, phrase or qualifier
+//	tag:cloud              qualifier, see domain.QueryQualifiers
+//	author:"Jane Smith"    quoted qualifier value
+//	tag:cloud OR tag:ai    either side, binding tighter than the implicit AND
+//
+// A word with a colon that does not start with a known qualifier, such as
+// "Computing:" or "10:30", is free text. Malformed syntax is rejected with
+// an error wrapping domain.ErrInvalidQuery, so no raw Elasticsearch query
+// syntax ever reaches the cluster.
+package querylang
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/oSoloTurk/multiple-kind-search/internal/domain"
+)
+
+const (
+	// MaxQueryLength bounds the query in bytes.
+	MaxQueryLength = 1024
+	// MaxClauses bounds the number of clauses, OR alternatives included.
+	MaxClauses = 64
+
+	orKeyword = "OR"
+)
+
+// Parse turns a user query into its structured form.
+func Parse(input string) (*domain.ParsedQuery, error) {
+	if len(input) > MaxQueryLength {
+		return nil, fmt.Errorf("%w: longer than %d characters", domain.ErrInvalidQuery, MaxQueryLength)
+	}
+	if !utf8.ValidString(input) {
+		return nil, fmt.Errorf("%w: not valid UTF-8", domain.ErrInvalidQuery)
+	}
+
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("%w: empty query", domain.ErrInvalidQuery)
+	}
+
+	query := &domain.ParsedQuery{Clauses: make([]domain.QueryClause, 0)}
+	count := 0
+	for i := 0; i < len(tokens); i++ {
+		if tokens[i].or {
+			return nil, fmt.Errorf("%w: OR at position %d needs a clause before it", domain.ErrInvalidQuery, tokens[i].pos)
+		}
+
+		clause := tokens[i].clause
+		alternatives := []domain.QueryClause{clause}
+		for i+1 < len(tokens) && tokens[i+1].or {
+			if i+2 >= len(tokens) || tokens[i+2].or {
+				return nil, fmt.Errorf("%w: OR at position %d needs a clause after it", domain.ErrInvalidQuery, tokens[i+1].pos)
+			}
+			alternatives = append(alternatives, tokens[i+2].clause)
+			i += 2
+		}
+
+		count += len(alternatives)
+		if count > MaxClauses {
+			return nil, fmt.Errorf("%w: more than %d clauses", domain.ErrInvalidQuery, MaxClauses)
+		}
+
+		if len(alternatives) == 1 {
+			query.Clauses = append(query.Clauses, clause)
+		} else {
+			query.Clauses = append(query.Clauses, domain.QueryClause{
+				Type: domain.OrClause,
+				Any:  alternatives,
+			})
+		}
+	}
+
+	return query, nil
+}
+
+// token is either the OR keyword or a single clause.
+type token struct {
+	pos    int
+	or     bool
+	clause domain.QueryClause
+}
+
+func tokenize(input string) ([]token, error) {
+	tokens := make([]token, 0)
+	pos := 0
+	for pos < len(input) {
+		r, width := utf8.DecodeRuneInString(input[pos:])
+		if unicode.IsSpace(r) {
+			pos += width
+			continue
+		}
+
+		start := pos
+		clause := domain.QueryClause{}
+		if r == '-' {
+			clause.Negated = true
+			pos += width
+			if pos >= len(input) || startsWithSpace(input[pos:]) {
+				return nil, fmt.Errorf("%w: nothing to exclude after - at position %d", domain.ErrInvalidQuery, start)
+			}
+		}
+
+		if input[pos] == '"' {
+			phrase, next, err := readPhrase(input, pos)
+			if err != nil {
+				return nil, err
+			}
+			clause.Type = domain.PhraseClause
+			clause.Text = phrase
+			pos = next
+			tokens = append(tokens, token{pos: start, clause: clause})
+			continue
+		}
+
+		word, next := readWord(input, pos)
+		pos = next
+
+		if word == orKeyword && !clause.Negated {
+			tokens = append(tokens, token{pos: start, or: true})
+			continue
+		}
+
+		if field, value, ok := strings.Cut(word, ":"); ok && isQualifier(field) {
+			field = strings.ToLower(field)
+			clause.Type = domain.FieldClause
+			clause.Field = field
+
+			if value == "" && pos < len(input) && input[pos] == '"' {
+				phrase, next, err := readPhrase(input, pos)
+				if err != nil {
+					return nil, err
+				}
+				value = phrase
+				clause.Phrase = true
+				pos = next
+			}
+			if value == "" {
+				return nil, fmt.Errorf("%w: qualifier %q at position %d has no value", domain.ErrInvalidQuery, field, start)
+			}
+			clause.Text = value
+			tokens = append(tokens, token{pos: start, clause: clause})
+			continue
+		}
+
+		clause.Type = domain.TermClause
+		clause.Text = word
+		tokens = append(tokens, token{pos: start, clause: clause})
+	}
+	return tokens, nil
+}
+
+// readPhrase reads the quoted phrase starting at the opening quote at pos and
+// returns it with the position after the closing quote.
+func readPhrase(input string, pos int) (string, int, error) {
+	end := strings.IndexByte(input[pos+1:], '"')
+	if end < 0 {
+		return "", 0, fmt.Errorf("%w: unterminated quote at position %d", domain.ErrInvalidQuery, pos)
+	}
+	phrase := strings.TrimSpace(input[pos+1 : pos+1+end])
+	if phrase == "" {
+		return "", 0, fmt.Errorf("%w: empty phrase at position %d", domain.ErrInvalidQuery, pos)
+	}
+	return phrase, pos + end + 2, nil
+}
+
+// readWord reads up to the next space or quote.
+func readWord(input string, pos int) (string, int) {
+	start := pos
+	for pos < len(input) {
+		r, width := utf8.DecodeRuneInString(input[pos:])
+		if unicode.IsSpace(r) || r == '"' {
+			break
+		}
+		pos += width
+	}
+	return input[start:pos], pos
+}
+
+func startsWithSpace(s string) bool {
+	r, _ := utf8.DecodeRuneInString(s)
+	return unicode.IsSpace(r)
+}
+
+// isQualifier reports whether the text before a colon is one of
+// domain.QueryQualifiers, in any case, rather than part of ordinary text.
+func isQualifier(field string) bool {
+	return domain.QueryQualifiers[strings.ToLower(field)]
+}
@@ -0,0 +1,138 @@
+package querylang
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/oSoloTurk/multiple-kind-search/internal/domain"
+)
+
+func term(text string) domain.QueryClause {
+	return domain.QueryClause{Type: domain.TermClause, Text: text}
+}
+
+func field(name, text string) domain.QueryClause {
+	return domain.QueryClause{Type: domain.FieldClause, Field: name, Text: text}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []domain.QueryClause
+	}{
+		{
+			name:  "free text",
+			input: "cloud  computing",
+			want:  []domain.QueryClause{term("cloud"), term("computing")},
+		},
+		{
+			name:  "phrase",
+			input: `"edge computing"`,
+			want:  []domain.QueryClause{{Type: domain.PhraseClause, Text: "edge computing"}},
+		},
+		{
+			name:  "negated term and phrase",
+			input: `-serverless -"cold start"`,
+			want: []domain.QueryClause{
+				{Type: domain.TermClause, Text: "serverless", Negated: true},
+				{Type: domain.PhraseClause, Text: "cold start", Negated: true},
+			},
+		},
+		{
+			name:  "qualifier",
+			input: "tag:cloud",
+			want:  []domain.QueryClause{field(domain.TagQualifier, "cloud")},
+		},
+		{
+			name:  "qualifier in upper case",
+			input: "Tag:Cloud",
+			want:  []domain.QueryClause{field(domain.TagQualifier, "Cloud")},
+		},
+		{
+			name:  "quoted qualifier value",
+			input: `author:"Jane Smith"`,
+			want: []domain.QueryClause{
+				{Type: domain.FieldClause, Field: domain.AuthorQualifier, Text: "Jane Smith", Phrase: true},
+			},
+		},
+		{
+			name:  "negated qualifier",
+			input: "-tag:ai",
+			want: []domain.QueryClause{
+				{Type: domain.FieldClause, Field: domain.TagQualifier, Text: "ai", Negated: true},
+			},
+		},
+		{
+			name:  "unknown qualifier is free text",
+			input: "The Future of Cloud Computing: Trends to Watch",
+			want: []domain.QueryClause{
+				term("The"), term("Future"), term("of"), term("Cloud"),
+				term("Computing:"), term("Trends"), term("to"), term("Watch"),
+			},
+		},
+		{
+			name:  "word with a colon inside is free text",
+			input: "note:worthy 10:30",
+			want:  []domain.QueryClause{term("note:worthy"), term("10:30")},
+		},
+		{
+			name:  "or",
+			input: "tag:cloud OR tag:ai security",
+			want: []domain.QueryClause{
+				{
+					Type: domain.OrClause,
+					Any:  []domain.QueryClause{field(domain.TagQualifier, "cloud"), field(domain.TagQualifier, "ai")},
+				},
+				term("security"),
+			},
+		},
+		{
+			name:  "lower case or is a word",
+			input: "cloud or edge",
+			want:  []domain.QueryClause{term("cloud"), term("or"), term("edge")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tt.input, err)
+			}
+			if !reflect.DeepEqual(parsed.Clauses, tt.want) {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.input, parsed.Clauses, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "empty", input: "   "},
+		{name: "too long", input: strings.Repeat("a", MaxQueryLength+1)},
+		{name: "invalid utf-8", input: "cloud \xff"},
+		{name: "unterminated quote", input: `"edge computing`},
+		{name: "empty phrase", input: `"  "`},
+		{name: "nothing to exclude", input: "cloud - edge"},
+		{name: "qualifier without value", input: "tag: cloud"},
+		{name: "leading or", input: "OR cloud"},
+		{name: "trailing or", input: "cloud OR"},
+		{name: "double or", input: "cloud OR OR edge"},
+		{name: "too many clauses", input: strings.Repeat("a ", MaxClauses+1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := Parse(tt.input)
+			if !errors.Is(err, domain.ErrInvalidQuery) {
+				t.Errorf("Parse(%q) = %+v, %v, want an error wrapping ErrInvalidQuery", tt.input, parsed, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	elastic "github.com/elastic/go-elasticsearch/v8"
+	"github.com/oSoloTurk/multiple-kind-search/internal/domain"
+	"github.com/oSoloTurk/multiple-kind-search/internal/logger"
+)
+
+const alertIndex = "alerts"
+
+// maxAlertsPerArticle caps the saved searches one article change alerts.
+const maxAlertsPerArticle = 100
+
+type bulkResponse struct {
+	Errors bool                        `json:"errors"`
+	Items  []map[string]bulkItemResult `json:"items"`
+}
+
+type bulkItemResult struct {
+	Status int         `json:"status"`
+	Error  *errorCause `json:"error"`
+}
+
+type alertRepository struct {
+	client   *elastic.Client
+	notifier domain.AlertNotifier
+}
+
+// NewAlertRepository returns a repository that percolates news through the
+// saved searches and stores the alerts raised, pushing them to the notifier
+// unless it is nil.
+func NewAlertRepository(client *elastic.Client, notifier domain.AlertNotifier) domain.AlertRepository {
+	return &alertRepository{client: client, notifier: notifier}
+}
+
+// Match raises one alert per saved search and article: an article matching
+// a saved search it already alerted about, as most updates do, raises none.
+func (r *alertRepository) Match(news *domain.News, event domain.AlertEvent) ([]domain.Alert, error) {
+	searches, err := r.percolate(news)
+	if err != nil {
+		return nil, err
+	}
+	if len(searches) == 0 {
+		return make([]domain.Alert, 0), nil
+	}
+
+	now := time.Now()
+	alerts := make([]domain.Alert, 0, len(searches))
+	for _, search := range searches {
+		alerts = append(alerts, domain.Alert{
+			ID:              alertID(search.ID, news.ID),
+			SavedSearchID:   search.ID,
+			SavedSearchName: search.Name,
+			NewsID:          news.ID,
+			NewsTitle:       news.Title,
+			Event:           event,
+			CreatedAt:       now,
+		})
+	}
+
+	alerts, err = r.store(alerts)
+	if err != nil {
+		return nil, err
+	}
+	if len(alerts) == 0 {
+		return alerts, nil
+	}
+
+	logger.Logger.Info().
+		Str("newsId", news.ID).
+		Str("event", string(event)).
+		Int("alerts", len(alerts)).
+		Msg("Raised saved search alerts")
+
+	if r.notifier != nil {
+		// Delivery must not hold up writing news
+		go func() {
+			if err := r.notifier.Notify(alerts); err != nil {
+				logger.Logger.Warn().Err(err).Str("newsId", news.ID).Msg("Failed to push alerts")
+			}
+		}()
+	}
+	return alerts, nil
+}
+
+// percolate returns the saved searches matching the article.
+func (r *alertRepository) percolate(news *domain.News) ([]domain.SavedSearch, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"query": map[string]interface{}{
+			"percolate": map[string]interface{}{
+				"field":    savedSearchQueryField,
+				"document": news,
+			},
+		},
+		"size":    maxAlertsPerArticle,
+		"_source": []string{"id", "name"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := r.client.Search(
+		r.client.Search.WithIndex(savedSearchIndex),
+		r.client.Search.WithBody(strings.NewReader(string(body))),
+		r.client.Search.WithContext(context.Background()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var result searchResponse
+	if err := decodeResponse(res, &result); err != nil {
+		return nil, fmt.Errorf("failed to match saved searches: %w", err)
+	}
+
+	searches := make([]domain.SavedSearch, 0, len(result.Hits.Hits))
+	for _, hit := range result.Hits.Hits {
+		var search domain.SavedSearch
+		if err := decodeSource(hit.Source, &search); err != nil {
+			return nil, err
+		}
+		searches = append(searches, search)
+	}
+	return searches, nil
+}
+
+// alertID identifies the alert of a saved search about an article, so that
+// it is raised once.
+func alertID(savedSearchID, newsID string) string {
+	return savedSearchID + ":" + newsID
+}
+
+// store creates the alerts with a single bulk request, returning those not
+// raised before.
+func (r *alertRepository) store(alerts []domain.Alert) ([]domain.Alert, error) {
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, alert := range alerts {
+		action := map[string]interface{}{
+			"create": map[string]interface{}{"_index": alertIndex, "_id": alert.ID},
+		}
+		if err := encoder.Encode(action); err != nil {
+			return nil, err
+		}
+		if err := encoder.Encode(alert); err != nil {
+			return nil, err
+		}
+	}
+
+	res, err := r.client.Bulk(
+		&body,
+		r.client.Bulk.WithContext(context.Background()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var result bulkResponse
+	if err := decodeResponse(res, &result); err != nil {
+		return nil, fmt.Errorf("failed to store alerts: %w", err)
+	}
+
+	created := make([]domain.Alert, 0, len(alerts))
+	for i, item := range result.Items {
+		outcome := item["create"]
+		switch {
+		case outcome.Status == http.StatusConflict:
+			// Raised before
+		case outcome.Error != nil:
+			return nil, fmt.Errorf("failed to store alerts: %w", outcome.Error)
+		default:
+			created = append(created, alerts[i])
+		}
+	}
+	return created, nil
+}
+
+func (r *alertRepository) List(filter domain.AlertFilter) (*domain.AlertPage, error) {
+	query, err := buildListQuery(filter.ListFilter)
+	if err != nil {
+		return nil, err
+	}
+	if filter.SavedSearchID != "" {
+		query["query"] = map[string]interface{}{
+			"term": map[string]interface{}{"savedSearchId": filter.SavedSearchID},
+		}
+	}
+
+	body, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := r.client.Search(
+		r.client.Search.WithIndex(alertIndex),
+		r.client.Search.WithBody(strings.NewReader(string(body))),
+		r.client.Search.WithContext(context.Background()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	page := &domain.AlertPage{Items: make([]domain.Alert, 0)}
+	var result searchResponse
+	if err := decodeResponse(res, &result); errors.Is(err, domain.ErrNotFound) {
+		// No alert was raised yet
+		return page, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to list alerts: %w", err)
+	}
+
+	hits := result.Hits.Hits
+	for i, hit := range hits {
+		if i == filter.Size {
+			// The extra hit only signals that another page follows
+			page.Next = encodeCursor(hits[i-1].Sort)
+			break
+		}
+
+		var alert domain.Alert
+		if err := decodeSource(hit.Source, &alert); err != nil {
+			return nil, err
+		}
+		page.Items = append(page.Items, alert)
+	}
+
+	return page, nil
+}
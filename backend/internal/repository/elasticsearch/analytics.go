@@ -0,0 +1,287 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	elastic "github.com/elastic/go-elasticsearch/v8"
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+	"github.com/oSoloTurk/multiple-kind-search/internal/domain"
+)
+
+const analyticsIndex = "search-analytics"
+
+// Kinds of analytics events, told apart by the event field.
+const (
+	searchEventKind = "search"
+	clickEventKind  = "click"
+)
+
+type searchEventDocument struct {
+	Event string `json:"event"`
+	domain.SearchEvent
+}
+
+type clickEventDocument struct {
+	Event string `json:"event"`
+	domain.ClickEvent
+}
+
+type analyticsRepository struct {
+	client *elastic.Client
+}
+
+// NewAnalyticsRepository returns a repository keeping searches and clicks
+// as events in one index, which the reports aggregate.
+func NewAnalyticsRepository(client *elastic.Client) domain.AnalyticsRepository {
+	return &analyticsRepository{client: client}
+}
+
+func (r *analyticsRepository) RecordSearch(event *domain.SearchEvent) error {
+	return r.record(event.SearchID, searchEventDocument{Event: searchEventKind, SearchEvent: *event})
+}
+
+func (r *analyticsRepository) RecordClick(click *domain.ClickEvent) error {
+	return r.record("", clickEventDocument{Event: clickEventKind, ClickEvent: *click})
+}
+
+// record indexes an event, under the given ID unless it is empty.
+func (r *analyticsRepository) record(id string, document interface{}) error {
+	body, err := json.Marshal(document)
+	if err != nil {
+		return err
+	}
+
+	options := []func(*esapi.IndexRequest){r.client.Index.WithContext(context.Background())}
+	if id != "" {
+		options = append(options, r.client.Index.WithDocumentID(id))
+	}
+	res, err := r.client.Index(analyticsIndex, strings.NewReader(string(body)), options...)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if err := decodeResponse(res, nil); err != nil {
+		return fmt.Errorf("failed to record analytics event: %w", err)
+	}
+	return nil
+}
+
+func (r *analyticsRepository) GetSearch(searchID string) (*domain.SearchEvent, error) {
+	res, err := r.client.Get(
+		analyticsIndex,
+		searchID,
+		r.client.Get.WithContext(context.Background()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var result getResponse
+	if err := decodeResponse(res, &result); err != nil {
+		return nil, fmt.Errorf("failed to get search %s: %w", searchID, err)
+	}
+
+	var document searchEventDocument
+	if err := decodeSource(result.Source, &document); err != nil {
+		return nil, err
+	}
+	if document.Event != searchEventKind {
+		return nil, fmt.Errorf("%w: search %s", domain.ErrNotFound, searchID)
+	}
+	return &document.SearchEvent, nil
+}
+
+func (r *analyticsRepository) TopQueries(filter domain.ReportFilter) ([]domain.QueryCount, error) {
+	return r.queryCounts(filter, eventFilter(searchEventKind))
+}
+
+// ZeroResultQueries counts the searches whose query completed without
+// results, including those autocorrect rescued by searching a correction
+// instead. Failed and partial searches are left out, as their results are
+// missing.
+func (r *analyticsRepository) ZeroResultQueries(filter domain.ReportFilter) ([]domain.QueryCount, error) {
+	return r.queryCounts(filter,
+		eventFilter(searchEventKind),
+		map[string]interface{}{
+			"bool": map[string]interface{}{
+				"should": []interface{}{
+					map[string]interface{}{"term": map[string]interface{}{"total": 0}},
+					map[string]interface{}{"exists": map[string]interface{}{"field": "correctedQuery"}},
+				},
+				"minimum_should_match": 1,
+			},
+		},
+		map[string]interface{}{"term": map[string]interface{}{"partial": false}},
+		map[string]interface{}{
+			"bool": map[string]interface{}{
+				"must_not": map[string]interface{}{
+					"exists": map[string]interface{}{"field": "error"},
+				},
+			},
+		},
+	)
+}
+
+// queryCounts counts the events in the report's range that match every
+// filter, per normalized query, most frequent first.
+func (r *analyticsRepository) queryCounts(filter domain.ReportFilter, filters ...interface{}) ([]domain.QueryCount, error) {
+	result, err := r.report(map[string]interface{}{
+		"size":  0,
+		"query": reportQuery(filter, filters...),
+		"aggs": map[string]interface{}{
+			"queries": map[string]interface{}{
+				"terms": map[string]interface{}{
+					"field": "normalizedQuery",
+					"size":  filter.Size,
+				},
+			},
+		},
+	})
+	if errors.Is(err, domain.ErrNotFound) {
+		// Nothing was recorded yet
+		return make([]domain.QueryCount, 0), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make([]domain.QueryCount, 0)
+	for _, bucket := range GetBuckets(result.Aggregations, "queries") {
+		counts = append(counts, domain.QueryCount{Query: bucket.Key, Count: bucket.Count})
+	}
+	return counts, nil
+}
+
+type countAggregation struct {
+	DocCount int64 `json:"doc_count"`
+}
+
+type clickAggregation struct {
+	DocCount int64 `json:"doc_count"`
+	Clicked  struct {
+		Value int64 `json:"value"`
+	} `json:"clicked"`
+}
+
+type queryClicksAggregation struct {
+	Buckets []struct {
+		Key      string           `json:"key"`
+		Searches countAggregation `json:"searches"`
+		Clicks   clickAggregation `json:"clicks"`
+	} `json:"buckets"`
+}
+
+// ClickThrough counts the searches and the distinct searches clicked in the
+// report's range, overall and for the most searched queries.
+func (r *analyticsRepository) ClickThrough(filter domain.ReportFilter) (*domain.ClickThroughReport, error) {
+	searches := map[string]interface{}{
+		"filter": eventFilter(searchEventKind),
+	}
+	clicks := map[string]interface{}{
+		"filter": eventFilter(clickEventKind),
+		"aggs": map[string]interface{}{
+			"clicked": map[string]interface{}{
+				"cardinality": map[string]interface{}{"field": "searchId"},
+			},
+		},
+	}
+
+	report := &domain.ClickThroughReport{Queries: make([]domain.QueryClickThrough, 0)}
+	result, err := r.report(map[string]interface{}{
+		"size":  0,
+		"query": reportQuery(filter),
+		"aggs": map[string]interface{}{
+			"searches": searches,
+			"clicks":   clicks,
+			"queries": map[string]interface{}{
+				"terms": map[string]interface{}{
+					"field": "normalizedQuery",
+					"size":  filter.Size,
+					"order": map[string]interface{}{"searches": "desc"},
+				},
+				"aggs": map[string]interface{}{
+					"searches": searches,
+					"clicks":   clicks,
+				},
+			},
+		},
+	})
+	if errors.Is(err, domain.ErrNotFound) {
+		return report, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var total countAggregation
+	var clicked clickAggregation
+	var queries queryClicksAggregation
+	for name, v := range map[string]interface{}{"searches": &total, "clicks": &clicked, "queries": &queries} {
+		if err := json.Unmarshal(result.Aggregations[name], v); err != nil {
+			return nil, fmt.Errorf("failed to decode %s aggregation: %w", name, err)
+		}
+	}
+
+	report.ClickThrough = clickThrough(total.DocCount, clicked.Clicked.Value)
+	report.Clicks = clicked.DocCount
+	for _, bucket := range queries.Buckets {
+		report.Queries = append(report.Queries, domain.QueryClickThrough{
+			Query:        bucket.Key,
+			ClickThrough: clickThrough(bucket.Searches.DocCount, bucket.Clicks.Clicked.Value),
+		})
+	}
+	return report, nil
+}
+
+func clickThrough(searches, clicked int64) domain.ClickThrough {
+	rate := 0.0
+	if searches > 0 {
+		rate = float64(clicked) / float64(searches)
+	}
+	return domain.ClickThrough{Searches: searches, ClickedSearches: clicked, Rate: rate}
+}
+
+// report runs an aggregation over the analytics index.
+func (r *analyticsRepository) report(query map[string]interface{}) (*searchResponse, error) {
+	body, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := r.client.Search(
+		r.client.Search.WithIndex(analyticsIndex),
+		r.client.Search.WithBody(strings.NewReader(string(body))),
+		r.client.Search.WithContext(context.Background()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var result searchResponse
+	if err := decodeResponse(res, &result); err != nil {
+		return nil, fmt.Errorf("failed to build analytics report: %w", err)
+	}
+	return &result, nil
+}
+
+// reportQuery matches the events in the report's range that match every
+// filter.
+func reportQuery(filter domain.ReportFilter, filters ...interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"bool": map[string]interface{}{
+			"filter": append(filters, dateRangeFilter("timestamp", filter.Range)),
+		},
+	}
+}
+
+func eventFilter(kind string) map[string]interface{} {
+	return map[string]interface{}{
+		"term": map[string]interface{}{"event": kind},
+	}
+}
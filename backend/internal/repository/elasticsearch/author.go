@@ -3,6 +3,7 @@ package elasticsearch
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"strings"
 	"time"
 
@@ -45,7 +46,7 @@ func (r *authorRepository) Create(author *domain.Author) error {
 	}
 	defer res.Body.Close()
 
-	return nil
+	return decodeResponse(res, nil)
 }
 
 func (r *authorRepository) GetByID(id string) (*domain.Author, error) {
@@ -59,23 +60,13 @@ func (r *authorRepository) GetByID(id string) (*domain.Author, error) {
 	}
 	defer res.Body.Close()
 
-	var result map[string]interface{}
-	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
-		return nil, err
-	}
-
-	source, ok := result["_source"].(map[string]interface{})
-	if !ok {
-		return nil, nil // Document not found
-	}
-
-	sourceBytes, err := json.Marshal(source)
-	if err != nil {
-		return nil, err
+	var result getResponse
+	if err := decodeResponse(res, &result); err != nil {
+		return nil, fmt.Errorf("failed to get author %s: %w", id, err)
 	}
 
 	var author domain.Author
-	if err := json.Unmarshal(sourceBytes, &author); err != nil {
+	if err := decodeSource(result.Source, &author); err != nil {
 		return nil, err
 	}
 
@@ -103,6 +94,47 @@ func (r *authorRepository) Update(author *domain.Author) error {
 	}
 	defer res.Body.Close()
 
+	if err := decodeResponse(res, nil); err != nil {
+		return fmt.Errorf("failed to update author %s: %w", author.ID, err)
+	}
+	return r.updateNewsAuthor(author)
+}
+
+// updateNewsAuthor refreshes the copy of the author's name and image held by
+// each of their articles.
+func (r *authorRepository) updateNewsAuthor(author *domain.Author) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"query": map[string]interface{}{
+			"term": map[string]interface{}{
+				"authorID": author.ID,
+			},
+		},
+		"script": map[string]interface{}{
+			"source": "ctx._source.authorName = params.name; ctx._source.authorImageUrl = params.imageUrl",
+			"params": map[string]interface{}{
+				"name":     author.Name,
+				"imageUrl": author.ImageURL,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	res, err := r.client.UpdateByQuery(
+		[]string{newsIndex},
+		r.client.UpdateByQuery.WithBody(strings.NewReader(string(body))),
+		r.client.UpdateByQuery.WithConflicts("proceed"),
+		r.client.UpdateByQuery.WithContext(context.Background()),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if err := decodeResponse(res, nil); err != nil {
+		return fmt.Errorf("failed to update the author details of news by %s: %w", author.ID, err)
+	}
 	return nil
 }
 
@@ -117,15 +149,16 @@ func (r *authorRepository) Delete(id string) error {
 	}
 	defer res.Body.Close()
 
+	if err := decodeResponse(res, nil); err != nil {
+		return fmt.Errorf("failed to delete author %s: %w", id, err)
+	}
 	return nil
 }
 
-func (r *authorRepository) List() ([]domain.Author, error) {
-	query := map[string]interface{}{
-		"query": map[string]interface{}{
-			"match_all": map[string]interface{}{},
-		},
-		"size": 1000,
+func (r *authorRepository) List(filter domain.ListFilter) (*domain.AuthorPage, error) {
+	query, err := buildListQuery(filter)
+	if err != nil {
+		return nil, err
 	}
 
 	body, err := json.Marshal(query)
@@ -143,29 +176,27 @@ func (r *authorRepository) List() ([]domain.Author, error) {
 	}
 	defer res.Body.Close()
 
-	var result map[string]interface{}
-	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
-		return nil, err
+	var result searchResponse
+	if err := decodeResponse(res, &result); err != nil {
+		return nil, fmt.Errorf("failed to list authors: %w", err)
 	}
 
-	hits := result["hits"].(map[string]interface{})["hits"].([]interface{})
-	authors := make([]domain.Author, 0)
+	hits := result.Hits.Hits
+	page := &domain.AuthorPage{Items: make([]domain.Author, 0, len(hits))}
 
-	for _, hit := range hits {
-		hitMap := hit.(map[string]interface{})
-		source := hitMap["_source"]
-
-		sourceBytes, err := json.Marshal(source)
-		if err != nil {
-			return nil, err
+	for i, hit := range hits {
+		if i == filter.Size {
+			// The extra hit only signals that another page follows
+			page.Next = encodeCursor(hits[i-1].Sort)
+			break
 		}
 
 		var author domain.Author
-		if err := json.Unmarshal(sourceBytes, &author); err != nil {
+		if err := decodeSource(hit.Source, &author); err != nil {
 			return nil, err
 		}
-		authors = append(authors, author)
+		page.Items = append(page.Items, author)
 	}
 
-	return authors, nil
+	return page, nil
 }
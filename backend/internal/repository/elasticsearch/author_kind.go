@@ -0,0 +1,215 @@
+package elasticsearch
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/oSoloTurk/multiple-kind-search/internal/domain"
+)
+
+type authorKind struct{}
+
+// NewAuthorKind makes authors searchable by name and bio.
+func NewAuthorKind() SearchableKind {
+	return authorKind{}
+}
+
+func (authorKind) Type() domain.SearchResultType {
+	return domain.AuthorResultType
+}
+
+func (authorKind) Index() string {
+	return authorIndex
+}
+
+func (authorKind) Weight() float64 {
+	return 1
+}
+
+// Accepts rejects filters on tags and publication dates, which only apply
+// to news.
+func (authorKind) Accepts(filter domain.SearchFilter) bool {
+	return len(filter.Tags) == 0 &&
+		len(filter.Months) == 0 &&
+		filter.CreatedAt.IsZero() &&
+		filter.UpdatedAt.IsZero()
+}
+
+func (authorKind) Query(ctx context.Context, filter domain.SearchFilter) (map[string]interface{}, error) {
+	filters := make([]interface{}, 0)
+	if len(filter.AuthorIDs) > 0 {
+		filters = append(filters, map[string]interface{}{
+			"ids": map[string]interface{}{
+				"values": filter.AuthorIDs,
+			},
+		})
+	}
+	if filter.Language != "" {
+		filters = append(filters, languageFilter(filter.Language))
+	}
+
+	return map[string]interface{}{
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"must":   userQuery(filter.Parsed, textFields(filter.Language, "name", "bio"), filter.Fuzziness, authorQualifier(filter.Language)),
+				"filter": filters,
+			},
+		},
+		"highlight": highlightQuery(languageFields("name", filter.Language), languageFields("bio", filter.Language), filter),
+	}, nil
+}
+
+// authorQualifier matches the name, bio and author qualifiers, the text
+// ones through the fields of the language.
+func authorQualifier(language domain.Language) qualifierQuery {
+	return func(clause domain.QueryClause) (map[string]interface{}, bool) {
+		switch clause.Field {
+		case domain.NameQualifier:
+			return fieldQuery(languageFields("name", language), clause), true
+		case domain.BioQualifier:
+			return fieldQuery(languageFields("bio", language), clause), true
+		case domain.AuthorQualifier:
+			return map[string]interface{}{
+				"term": map[string]interface{}{
+					"name.keyword": clause.Text,
+				},
+			}, true
+		default:
+			return nil, false
+		}
+	}
+}
+
+// maxResolvedAuthors caps the authors looked up for a set of names.
+const maxResolvedAuthors = 100
+
+// LookupQuery searches the authors whose name matches, exactly and ignoring
+// case, any author name of the search, boosted or in author qualifiers of
+// the query. Each name has a named term query of its own.
+func (authorKind) LookupQuery(filter domain.SearchFilter) ([]string, map[string]interface{}) {
+	names := make([]string, 0)
+	for _, boost := range filter.Boosts {
+		if boost.Name != "" {
+			names = append(names, boost.Name)
+		}
+	}
+	var collect func(clauses []domain.QueryClause)
+	collect = func(clauses []domain.QueryClause) {
+		for _, clause := range clauses {
+			if clause.Type == domain.FieldClause && clause.Field == domain.AuthorQualifier {
+				names = append(names, clause.Text)
+			}
+			collect(clause.Any)
+		}
+	}
+	collect(filter.Parsed.Clauses)
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	clauses := make([]interface{}, 0, len(names))
+	for i, name := range names {
+		clauses = append(clauses, map[string]interface{}{
+			"term": map[string]interface{}{
+				"name.keyword": map[string]interface{}{
+					"value": name,
+					"_name": strconv.Itoa(i),
+				},
+			},
+		})
+	}
+
+	return names, map[string]interface{}{
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"should": clauses,
+			},
+		},
+		"_source": false,
+		"size":    maxResolvedAuthors,
+	}
+}
+
+// Unresolved returns the names that matched no author in the response to
+// LookupQuery.
+func (authorKind) Unresolved(names []string, result *searchResponse) []string {
+	found := make([]bool, len(names))
+	for _, hit := range result.Hits.Hits {
+		for _, name := range hit.MatchedQueries {
+			i, err := strconv.Atoi(name)
+			if err != nil || i < 0 || i >= len(names) {
+				continue
+			}
+			found[i] = true
+		}
+	}
+
+	unresolved := make([]string, 0)
+	seen := make(map[string]bool)
+	for i, name := range names {
+		if !found[i] && !seen[name] {
+			seen[name] = true
+			unresolved = append(unresolved, name)
+		}
+	}
+	return unresolved
+}
+
+// authorDocument is an author as stored, with the number of news written by
+// the author that the news repository keeps up to date.
+type authorDocument struct {
+	domain.Author
+	ArticleCount int64 `json:"articleCount"`
+}
+
+func (authorKind) MapHit(hit searchHit) (domain.SearchResult, error) {
+	var author authorDocument
+	if err := decodeSource(hit.Source, &author); err != nil {
+		return domain.SearchResult{}, err
+	}
+
+	return domain.SearchResult{
+		ID:           author.ID,
+		Title:        author.Name,
+		Content:      author.Bio,
+		Highlights:   GetHighlights(hit, languageFields("name", ""), languageFields("bio", "")),
+		Score:        hit.Score,
+		Type:         domain.AuthorResultType,
+		ImageURL:     author.ImageURL,
+		ArticleCount: &author.ArticleCount,
+	}, nil
+}
+
+func (authorKind) SuggestQuery(prefix string, size int) map[string]interface{} {
+	return map[string]interface{}{
+		"size":    size,
+		"_source": []string{"id", "name"},
+		"query": map[string]interface{}{
+			"multi_match": map[string]interface{}{
+				"query":  prefix,
+				"type":   "bool_prefix",
+				"fields": searchAsYouTypeFields("nameSuggest"),
+			},
+		},
+	}
+}
+
+func (authorKind) MapSuggestions(result *searchResponse) []domain.Suggestion {
+	suggestions := make([]domain.Suggestion, 0, len(result.Hits.Hits))
+	for _, hit := range result.Hits.Hits {
+		var author domain.Author
+		if err := decodeSource(hit.Source, &author); err != nil {
+			continue
+		}
+		suggestions = append(suggestions, domain.Suggestion{
+			Text: author.Name,
+			Type: domain.AuthorResultType,
+			ID:   author.ID,
+		})
+	}
+	return suggestions
+}
+
+func (authorKind) SpellcheckFields() []string {
+	return []string{"name", "bio"}
+}
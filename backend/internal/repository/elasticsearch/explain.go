@@ -0,0 +1,151 @@
+package elasticsearch
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/oSoloTurk/multiple-kind-search/internal/domain"
+)
+
+// termWeight matches the explanation of the score of a term, a phrase or
+// synonyms in a field, such as weight(title.en:cloud in 3).
+var termWeight = regexp.MustCompile(`^weight\((.+) in \d+\)`)
+
+// decayFunction starts the explanation of the recency decay of recencyScore.
+const decayFunction = "Function for field createdAt"
+
+// explanation is the _explanation of a hit.
+type explanation struct {
+	Value       float64       `json:"value"`
+	Description string        `json:"description"`
+	Details     []explanation `json:"details"`
+}
+
+func (e explanation) node() *domain.ExplanationNode {
+	node := &domain.ExplanationNode{Value: e.Value, Description: e.Description}
+	for _, detail := range e.Details {
+		node.Details = append(node.Details, *detail.node())
+	}
+	return node
+}
+
+// explainScore summarises the explanation of a hit. Matches of the author
+// boosts of the search add up to the author boost, while author names
+// matched through qualifiers are field matches like any other term. The
+// merge part is filled in by mergeResults.
+func explainScore(hit searchHit, strategy domain.MergeStrategy, boosts []domain.AuthorBoost) *domain.ScoreExplanation {
+	if strategy == "" {
+		strategy = domain.MergeByScore
+	}
+	explained := &domain.ScoreExplanation{
+		Score:   hit.Score,
+		Matches: make([]domain.FieldMatch, 0),
+		Merge:   strategy,
+	}
+	if hit.Explanation == nil {
+		return explained
+	}
+	explained.Details = hit.Explanation.node()
+
+	matches := make([]weightedMatch, 0)
+	var walk func(node explanation)
+	walk = func(node explanation) {
+		if strings.HasPrefix(node.Description, decayFunction) {
+			decay := node.Value
+			explained.Decay = &decay
+			return
+		}
+		if match := termWeight.FindStringSubmatch(node.Description); match != nil {
+			if node.Value <= 0 {
+				return
+			}
+			field, term := splitWeightedTerm(match[1])
+			matches = append(matches, weightedMatch{
+				FieldMatch: domain.FieldMatch{Field: field, Term: term, Score: node.Value},
+				boosted:    node.boosted(),
+			})
+			return
+		}
+		for _, detail := range node.Details {
+			walk(detail)
+		}
+	}
+	walk(*hit.Explanation)
+
+	// An author both boosted and given in a qualifier matches the same term
+	// twice, and the match whose score shows a boost is taken for the boost
+	remaining := make(map[string]int, len(boosts))
+	for _, boost := range boosts {
+		if boost.ID != "" {
+			remaining[authorTerm("authorID", boost.ID)]++
+		} else {
+			remaining[authorTerm("authorName", boost.Name)]++
+		}
+	}
+	fromBoost := make([]bool, len(matches))
+	for _, boosted := range []bool{true, false} {
+		for i, match := range matches {
+			term := authorTerm(match.Field, match.Term)
+			if fromBoost[i] || match.boosted != boosted || remaining[term] == 0 {
+				continue
+			}
+			remaining[term]--
+			fromBoost[i] = true
+		}
+	}
+
+	for i, match := range matches {
+		if fromBoost[i] {
+			explained.AuthorBoost += match.Score
+		} else {
+			explained.Matches = append(explained.Matches, match.FieldMatch)
+		}
+	}
+	return explained
+}
+
+// weightedMatch is a term match of an explanation, boosted when the query
+// that matched it has a boost.
+type weightedMatch struct {
+	domain.FieldMatch
+	boosted bool
+}
+
+// boosted tells whether the score of a term was multiplied by a query boost,
+// which Lucene only lists among the factors of the score when it is not 1.
+func (e explanation) boosted() bool {
+	for _, detail := range e.Details {
+		if detail.Description == "boost" || detail.boosted() {
+			return true
+		}
+	}
+	return false
+}
+
+// authorTerm identifies the term of an author boost. Author names compare
+// ignoring case, as their keyword normalizer lowercases them.
+func authorTerm(field, term string) string {
+	if field == "authorName" {
+		term = strings.ToLower(term)
+	}
+	return field + ":" + term
+}
+
+// splitWeightedTerm splits field:term, as in a weight explanation, into its
+// field and term. Synonyms, written Synonym(field:a field:b), are joined as
+// a | b.
+func splitWeightedTerm(weighted string) (string, string) {
+	if inner, ok := strings.CutPrefix(weighted, "Synonym("); ok {
+		inner = strings.TrimSuffix(inner, ")")
+		var field string
+		terms := make([]string, 0)
+		for _, part := range strings.Fields(inner) {
+			var term string
+			field, term, _ = strings.Cut(part, ":")
+			terms = append(terms, term)
+		}
+		return field, strings.Join(terms, " | ")
+	}
+	field, term, _ := strings.Cut(weighted, ":")
+	return field, term
+}
@@ -0,0 +1,259 @@
+package elasticsearch
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/oSoloTurk/multiple-kind-search/internal/domain"
+)
+
+// newsExplanation is the _explanation Elasticsearch gives for a news hit of
+// cloud "edge computing" author:"Jane Smith", with Jane Smith boosted by
+// name and the fresh ranking profile. The author name is matched once by the
+// qualifier and once, boosted, by the author boost.
+const newsExplanation = `{
+  "value": 7.0823402,
+  "description": "function score, product of:",
+  "details": [
+    {
+      "value": 9.079923,
+      "description": "sum of:",
+      "details": [
+        {
+          "value": 6.4331884,
+          "description": "sum of:",
+          "details": [
+            {
+              "value": 1.3862944,
+              "description": "max of:",
+              "details": [
+                {
+                  "value": 1.3862944,
+                  "description": "weight(title.en:cloud in 3) [PerFieldSimilarity], result of:",
+                  "details": [
+                    {
+                      "value": 1.3862944,
+                      "description": "score(freq=1.0), computed as boost * idf * tf from:",
+                      "details": [
+                        {"value": 2.1972246, "description": "idf, computed as log(1 + (N - n + 0.5) / (n + 0.5)) from:", "details": [
+                          {"value": 1, "description": "n, number of documents containing term", "details": []},
+                          {"value": 12, "description": "N, total number of documents with field", "details": []}
+                        ]},
+                        {"value": 0.6309297, "description": "tf, computed as freq / (freq + k1 * (1 - b + b * dl / avgdl)) from:", "details": []}
+                      ]
+                    }
+                  ]
+                },
+                {
+                  "value": 0.5753642,
+                  "description": "weight(Synonym(content.en:cloud content.en:cloud_computing) in 3) [PerFieldSimilarity], result of:",
+                  "details": [
+                    {"value": 0.5753642, "description": "score(freq=2.0), computed as boost * idf * tf from:", "details": []}
+                  ]
+                }
+              ]
+            },
+            {
+              "value": 3.9594088,
+              "description": "max of:",
+              "details": [
+                {
+                  "value": 3.9594088,
+                  "description": "weight(title.en:\"edge computing\" in 3) [PerFieldSimilarity], result of:",
+                  "details": [
+                    {
+                      "value": 3.9594088,
+                      "description": "score(freq=1.0), computed as boost * idf * tf from:",
+                      "details": [
+                        {"value": 4.3944492, "description": "idf, sum of:", "details": []},
+                        {"value": 0.9010025, "description": "tf, computed as freq / (freq + k1 * (1 - b + b * dl / avgdl)) from:", "details": []}
+                      ]
+                    }
+                  ]
+                },
+                {
+                  "value": 0,
+                  "description": "weight(content.en:\"edge computing\" in 3) [PerFieldSimilarity], result of:",
+                  "details": []
+                }
+              ]
+            },
+            {
+              "value": 1.0874852,
+              "description": "weight(authorName:jane smith in 3) [PerFieldSimilarity], result of:",
+              "details": [
+                {
+                  "value": 1.0874852,
+                  "description": "score(freq=1.0), computed as boost * idf * tf from:",
+                  "details": [
+                    {"value": 1.0874852, "description": "idf, computed as log(1 + (N - n + 0.5) / (n + 0.5)) from:", "details": []},
+                    {"value": 1, "description": "tf, computed as freq / (freq + k1 * (1 - b + b * dl / avgdl)) from:", "details": []}
+                  ]
+                }
+              ]
+            }
+          ]
+        },
+        {
+          "value": 2.1749704,
+          "description": "weight(authorName:jane smith in 3) [PerFieldSimilarity], result of:",
+          "details": [
+            {
+              "value": 2.1749704,
+              "description": "score(freq=1.0), computed as boost * idf * tf from:",
+              "details": [
+                {"value": 2, "description": "boost", "details": []},
+                {"value": 1.0874852, "description": "idf, computed as log(1 + (N - n + 0.5) / (n + 0.5)) from:", "details": []},
+                {"value": 1, "description": "tf, computed as freq / (freq + k1 * (1 - b + b * dl / avgdl)) from:", "details": []}
+              ]
+            }
+          ]
+        },
+        {
+          "value": 0,
+          "description": "match on required clause, product of:",
+          "details": [
+            {"value": 0, "description": "# clause", "details": []},
+            {"value": 1, "description": "language:en", "details": []}
+          ]
+        }
+      ]
+    },
+    {
+      "value": 0.78,
+      "description": "min of:",
+      "details": [
+        {
+          "value": 0.78,
+          "description": "Function for field createdAt:",
+          "details": [
+            {"value": 0.78, "description": "exp(- MIN[Math.max(Math.abs(1.7093088E12(=doc value) - 1.7187264E12(=origin))) - 0.0(=offset), 0)] * 3.3010358E-10)", "details": []}
+          ]
+        },
+        {"value": 3.4028235e+38, "description": "maxBoost", "details": []}
+      ]
+    }
+  ]
+}`
+
+func TestExplainScore(t *testing.T) {
+	var explained explanation
+	if err := json.Unmarshal([]byte(newsExplanation), &explained); err != nil {
+		t.Fatal(err)
+	}
+	hit := searchHit{ID: "news-3", Score: 7.0823402, Explanation: &explained}
+
+	termMatches := []domain.FieldMatch{
+		{Field: "title.en", Term: "cloud", Score: 1.3862944},
+		{Field: "content.en", Term: "cloud | cloud_computing", Score: 0.5753642},
+		{Field: "title.en", Term: `"edge computing"`, Score: 3.9594088},
+	}
+	qualifierMatch := domain.FieldMatch{Field: "authorName", Term: "jane smith", Score: 1.0874852}
+	boostMatch := domain.FieldMatch{Field: "authorName", Term: "jane smith", Score: 2.1749704}
+
+	tests := []struct {
+		name        string
+		boosts      []domain.AuthorBoost
+		matches     []domain.FieldMatch
+		authorBoost float64
+	}{
+		{
+			name:        "boosted author matched by a qualifier too",
+			boosts:      []domain.AuthorBoost{{Name: "Jane Smith", Weight: 2}},
+			matches:     append(append([]domain.FieldMatch{}, termMatches...), qualifierMatch),
+			authorBoost: 2.1749704,
+		},
+		{
+			name:    "author qualifier without boosts",
+			matches: append(append([]domain.FieldMatch{}, termMatches...), qualifierMatch, boostMatch),
+		},
+		{
+			name:    "boost of another author",
+			boosts:  []domain.AuthorBoost{{ID: "author-7", Weight: 2}},
+			matches: append(append([]domain.FieldMatch{}, termMatches...), qualifierMatch, boostMatch),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := explainScore(hit, "", tt.boosts)
+			if got.Score != hit.Score || got.Merge != domain.MergeByScore {
+				t.Errorf("score = %v with %s, want %v with %s", got.Score, got.Merge, hit.Score, domain.MergeByScore)
+			}
+			if !reflect.DeepEqual(got.Matches, tt.matches) {
+				t.Errorf("matches = %+v, want %+v", got.Matches, tt.matches)
+			}
+			if math.Abs(got.AuthorBoost-tt.authorBoost) > 1e-9 {
+				t.Errorf("author boost = %v, want %v", got.AuthorBoost, tt.authorBoost)
+			}
+			if got.Decay == nil || *got.Decay != 0.78 {
+				t.Errorf("decay = %v, want 0.78", got.Decay)
+			}
+			if got.Details == nil || got.Details.Value != explained.Value || len(got.Details.Details) != 2 {
+				t.Errorf("details = %+v, want the full explanation", got.Details)
+			}
+		})
+	}
+}
+
+func TestExplainScoreAuthorIDBoost(t *testing.T) {
+	hit := searchHit{Score: 3.1, Explanation: &explanation{
+		Value:       3.1,
+		Description: "sum of:",
+		Details: []explanation{
+			{Value: 1.2, Description: "weight(content.en:cloud in 0) [PerFieldSimilarity], result of:"},
+			{
+				Value:       1.9,
+				Description: "weight(authorID:author-1 in 0) [PerFieldSimilarity], result of:",
+				Details: []explanation{{
+					Value:       1.9,
+					Description: "score(freq=1.0), computed as boost * idf * tf from:",
+					Details:     []explanation{{Value: 1.5, Description: "boost"}},
+				}},
+			},
+		},
+	}}
+
+	got := explainScore(hit, domain.MergeByRRF, []domain.AuthorBoost{{ID: "author-1", Weight: 1.5}})
+	want := []domain.FieldMatch{{Field: "content.en", Term: "cloud", Score: 1.2}}
+	if !reflect.DeepEqual(got.Matches, want) || got.AuthorBoost != 1.9 {
+		t.Errorf("matches = %+v with author boost %v, want %+v with 1.9", got.Matches, got.AuthorBoost, want)
+	}
+	if got.Decay != nil || got.Merge != domain.MergeByRRF {
+		t.Errorf("decay = %v with %s, want none with %s", got.Decay, got.Merge, domain.MergeByRRF)
+	}
+}
+
+func TestExplainScoreWithoutExplanation(t *testing.T) {
+	got := explainScore(searchHit{Score: 1.5}, domain.MergeByScore, nil)
+	want := &domain.ScoreExplanation{Score: 1.5, Matches: []domain.FieldMatch{}, Merge: domain.MergeByScore}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("explainScore = %+v, want %+v", got, want)
+	}
+}
+
+func TestSplitWeightedTerm(t *testing.T) {
+	tests := []struct {
+		weighted string
+		field    string
+		term     string
+	}{
+		{weighted: "title.en:cloud", field: "title.en", term: "cloud"},
+		{weighted: `title.en:"edge computing"`, field: "title.en", term: `"edge computing"`},
+		{weighted: "authorName:jane smith", field: "authorName", term: "jane smith"},
+		{weighted: "content.en:12:30", field: "content.en", term: "12:30"},
+		{weighted: "Synonym(content.en:cloud content.en:cloud_computing)", field: "content.en", term: "cloud | cloud_computing"},
+		{weighted: "Synonym(title.tr:bulut title.tr:bulut_bilişim title.tr:cloud)", field: "title.tr", term: "bulut | bulut_bilişim | cloud"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.weighted, func(t *testing.T) {
+			field, term := splitWeightedTerm(tt.weighted)
+			if field != tt.field || term != tt.term {
+				t.Errorf("splitWeightedTerm(%q) = %q, %q, want %q, %q", tt.weighted, field, term, tt.field, tt.term)
+			}
+		})
+	}
+}
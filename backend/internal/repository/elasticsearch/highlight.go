@@ -0,0 +1,94 @@
+package elasticsearch
+
+import (
+	"strings"
+
+	"github.com/oSoloTurk/multiple-kind-search/internal/domain"
+)
+
+// Elasticsearch wraps every match in these private use characters, which are
+// then stripped to turn fragments into plain text and match spans. Unlike
+// HTML tags they cannot be confused with markup inside the documents.
+const (
+	highlightStart = '\ue000'
+	highlightEnd   = '\ue001'
+)
+
+// highlightQuery highlights the title fields of a kind whole and its content
+// fields in fragments sized by the filter. Each text field is highlighted
+// through the fields it was searched in, whose analyzers recognise the
+// matched terms.
+func highlightQuery(titleFields, contentFields []string, filter domain.SearchFilter) map[string]interface{} {
+	fields := make(map[string]interface{}, len(titleFields)+len(contentFields))
+	for _, field := range titleFields {
+		fields[field] = map[string]interface{}{
+			"number_of_fragments": 0,
+		}
+	}
+	for _, field := range contentFields {
+		fields[field] = map[string]interface{}{
+			"fragment_size":       filter.FragmentSize,
+			"number_of_fragments": filter.Fragments,
+		}
+	}
+
+	return map[string]interface{}{
+		"fields":    fields,
+		"pre_tags":  []string{string(highlightStart)},
+		"post_tags": []string{string(highlightEnd)},
+	}
+}
+
+// GetHighlights reads the highlighted fragments of the title and content
+// fields of a hit, keyed by result field, taking the first of each list of
+// fields that has any. Fields without a match are left out, and a hit
+// without highlights has none.
+func GetHighlights(hit searchHit, titleFields, contentFields []string) map[string][]domain.HighlightFragment {
+	highlights := make(map[string][]domain.HighlightFragment)
+	for resultField, fields := range map[string][]string{
+		domain.TitleHighlight:   titleFields,
+		domain.ContentHighlight: contentFields,
+	} {
+		var values []string
+		for _, field := range fields {
+			if values = hit.Highlight[field]; len(values) > 0 {
+				break
+			}
+		}
+		if len(values) == 0 {
+			continue
+		}
+		fragments := make([]domain.HighlightFragment, 0, len(values))
+		for _, value := range values {
+			fragments = append(fragments, parseFragment(value))
+		}
+		highlights[resultField] = fragments
+	}
+	if len(highlights) == 0 {
+		return nil
+	}
+	return highlights
+}
+
+// parseFragment strips the highlight markers from a fragment, recording the
+// spans they enclosed. Unbalanced markers are dropped.
+func parseFragment(fragment string) domain.HighlightFragment {
+	var text strings.Builder
+	matches := make([]domain.HighlightSpan, 0)
+	position, start := 0, -1
+	for _, r := range fragment {
+		switch r {
+		case highlightStart:
+			start = position
+		case highlightEnd:
+			if start >= 0 && position > start {
+				matches = append(matches, domain.HighlightSpan{Start: start, End: position})
+			}
+			start = -1
+		default:
+			text.WriteRune(r)
+			position++
+		}
+	}
+	return domain.HighlightFragment{Text: text.String(), Matches: matches}
+}
@@ -0,0 +1,122 @@
+package elasticsearch
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/oSoloTurk/multiple-kind-search/internal/domain"
+)
+
+func TestParseFragment(t *testing.T) {
+	start, end := string(highlightStart), string(highlightEnd)
+	tests := []struct {
+		name     string
+		fragment string
+		want     domain.HighlightFragment
+	}{
+		{
+			name:     "no markers",
+			fragment: "cloud computing",
+			want:     domain.HighlightFragment{Text: "cloud computing", Matches: []domain.HighlightSpan{}},
+		},
+		{
+			name:     "single match",
+			fragment: "hybrid " + mark("cloud") + " setups",
+			want: domain.HighlightFragment{
+				Text:    "hybrid cloud setups",
+				Matches: []domain.HighlightSpan{{Start: 7, End: 12}},
+			},
+		},
+		{
+			name:     "multi-byte text counted in code points",
+			fragment: "Türkiye'de " + mark("bulut") + " büyüyor",
+			want: domain.HighlightFragment{
+				Text:    "Türkiye'de bulut büyüyor",
+				Matches: []domain.HighlightSpan{{Start: 11, End: 16}},
+			},
+		},
+		{
+			name:     "multi-byte match",
+			fragment: "🚀 " + mark("İşlem") + " hızı",
+			want: domain.HighlightFragment{
+				Text:    "🚀 İşlem hızı",
+				Matches: []domain.HighlightSpan{{Start: 2, End: 7}},
+			},
+		},
+		{
+			name:     "adjacent matches",
+			fragment: mark("edge") + mark("computing"),
+			want: domain.HighlightFragment{
+				Text:    "edgecomputing",
+				Matches: []domain.HighlightSpan{{Start: 0, End: 4}, {Start: 4, End: 13}},
+			},
+		},
+		{
+			name:     "unterminated match",
+			fragment: "cloud " + start + "edge",
+			want:     domain.HighlightFragment{Text: "cloud edge", Matches: []domain.HighlightSpan{}},
+		},
+		{
+			name:     "unopened match",
+			fragment: "cloud" + end + " edge",
+			want:     domain.HighlightFragment{Text: "cloud edge", Matches: []domain.HighlightSpan{}},
+		},
+		{
+			name:     "empty match",
+			fragment: "cloud " + start + end + "edge",
+			want:     domain.HighlightFragment{Text: "cloud edge", Matches: []domain.HighlightSpan{}},
+		},
+		{
+			name:     "reopened match keeps the last start",
+			fragment: start + "cloud " + mark("edge"),
+			want: domain.HighlightFragment{
+				Text:    "cloud edge",
+				Matches: []domain.HighlightSpan{{Start: 6, End: 10}},
+			},
+		},
+		{
+			name:     "empty fragment",
+			fragment: "",
+			want:     domain.HighlightFragment{Text: "", Matches: []domain.HighlightSpan{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseFragment(tt.fragment)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseFragment(%q) = %+v, want %+v", tt.fragment, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetHighlights(t *testing.T) {
+	titleFields := languageFields("title", "")
+	contentFields := languageFields("content", "")
+
+	t.Run("no highlight block", func(t *testing.T) {
+		if got := GetHighlights(searchHit{ID: "news-1"}, titleFields, contentFields); got != nil {
+			t.Errorf("GetHighlights = %+v, want nil", got)
+		}
+	})
+
+	t.Run("first highlighted field of each list", func(t *testing.T) {
+		hit := searchHit{Highlight: map[string][]string{
+			"title.en":   {mark("Cloud") + " computing"},
+			"title.tr":   {mark("Bulut") + " bilişim"},
+			"content.tr": {"hibrit " + mark("bulut")},
+		}}
+		want := map[string][]domain.HighlightFragment{
+			domain.TitleHighlight: {
+				{Text: "Cloud computing", Matches: []domain.HighlightSpan{{Start: 0, End: 5}}},
+			},
+			domain.ContentHighlight: {
+				{Text: "hibrit bulut", Matches: []domain.HighlightSpan{{Start: 7, End: 12}}},
+			},
+		}
+		if got := GetHighlights(hit, titleFields, contentFields); !reflect.DeepEqual(got, want) {
+			t.Errorf("GetHighlights = %+v, want %+v", got, want)
+		}
+	})
+}
@@ -0,0 +1,127 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/oSoloTurk/multiple-kind-search/internal/domain"
+)
+
+// SearchableKind describes one type of document the combined search fans
+// out over. Adding a new searchable entity means implementing this interface
+// and registering it, without touching SearchRepository.
+type SearchableKind interface {
+	// Type is the result type reported on every hit of this kind.
+	Type() domain.SearchResultType
+	// Index is the Elasticsearch index holding documents of this kind.
+	Index() string
+	// Query builds the search body for the filter. Size and hit counting are
+	// set by the repository and must be left out.
+	Query(ctx context.Context, filter domain.SearchFilter) (map[string]interface{}, error)
+	// MapHit converts a single hit of the search response into a result.
+	MapHit(hit searchHit) (domain.SearchResult, error)
+	// Weight multiplies the merged scores of this kind unless the request
+	// overrides it.
+	Weight() float64
+}
+
+// FilteredKind is implemented by kinds that cannot honour every filter. A
+// kind that does not accept the filter is left out of the search, since
+// none of its documents could match.
+type FilteredKind interface {
+	Accepts(filter domain.SearchFilter) bool
+}
+
+// FacetedKind is implemented by kinds that contribute facets. Aggregations
+// for the filter are added to the search body when facets are requested,
+// and Facets turns their results into buckets keyed by facet name.
+type FacetedKind interface {
+	Aggregations(filter domain.SearchFilter) map[string]interface{}
+	Facets(ctx context.Context, aggregations map[string]json.RawMessage) (map[string][]domain.FacetBucket, error)
+}
+
+// SuggestingKind is implemented by kinds that offer type-ahead suggestions.
+// SuggestQuery builds a complete search body and MapSuggestions reads the
+// suggestions, best first, from its response.
+type SuggestingKind interface {
+	SuggestQuery(prefix string, size int) map[string]interface{}
+	MapSuggestions(result *searchResponse) []domain.Suggestion
+}
+
+// CorrectingKind is implemented by kinds whose vocabulary is used to correct
+// misspelled queries. The first field scores candidate phrases and every
+// field generates candidate terms.
+type CorrectingKind interface {
+	SpellcheckFields() []string
+}
+
+// KindRegistry holds the searchable kinds in registration order.
+type KindRegistry struct {
+	kinds map[domain.SearchResultType]SearchableKind
+	order []domain.SearchResultType
+}
+
+func NewKindRegistry(kinds ...SearchableKind) *KindRegistry {
+	registry := &KindRegistry{kinds: make(map[domain.SearchResultType]SearchableKind)}
+	for _, kind := range kinds {
+		registry.Register(kind)
+	}
+	return registry
+}
+
+// Register adds a kind, replacing any kind already registered for its type.
+func (r *KindRegistry) Register(kind SearchableKind) {
+	if _, ok := r.kinds[kind.Type()]; !ok {
+		r.order = append(r.order, kind.Type())
+	}
+	r.kinds[kind.Type()] = kind
+}
+
+func (r *KindRegistry) Get(resultType domain.SearchResultType) (SearchableKind, bool) {
+	kind, ok := r.kinds[resultType]
+	return kind, ok
+}
+
+// Kinds returns every registered kind in registration order.
+func (r *KindRegistry) Kinds() []SearchableKind {
+	kinds := make([]SearchableKind, 0, len(r.order))
+	for _, resultType := range r.order {
+		kinds = append(kinds, r.kinds[resultType])
+	}
+	return kinds
+}
+
+// Select returns the kinds for the given result types, or every kind when no
+// type is given.
+func (r *KindRegistry) Select(types []domain.SearchResultType) ([]SearchableKind, error) {
+	if len(types) == 0 {
+		return r.Kinds(), nil
+	}
+
+	kinds := make([]SearchableKind, 0, len(types))
+	seen := make(map[domain.SearchResultType]bool, len(types))
+	for _, resultType := range types {
+		kind, ok := r.kinds[resultType]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", domain.ErrUnknownResultType, resultType)
+		}
+		if seen[resultType] {
+			continue
+		}
+		seen[resultType] = true
+		kinds = append(kinds, kind)
+	}
+	return kinds, nil
+}
+
+// ResolvingKind is implemented by kinds whose documents a search of any kind
+// may refer to by name, such as authors in boosts and qualifiers. LookupQuery
+// returns the names the filter refers to, none when it refers to no document
+// of the kind, with a search of the kind's index for them, and Unresolved
+// reads the names that matched no document from its response. The lookup
+// runs alongside the search, and every unresolved name is reported.
+type ResolvingKind interface {
+	LookupQuery(filter domain.SearchFilter) (names []string, query map[string]interface{})
+	Unresolved(names []string, response *searchResponse) []string
+}
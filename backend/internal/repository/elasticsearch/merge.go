@@ -0,0 +1,114 @@
+package elasticsearch
+
+import (
+	"math"
+	"sort"
+
+	"github.com/oSoloTurk/multiple-kind-search/internal/domain"
+)
+
+// rrfRankConstant is the k in 1/(k+rank), the value proposed in the original
+// reciprocal rank fusion paper.
+const rrfRankConstant = 60
+
+// Merger rescores the hits of every result type so that they can be sorted
+// into one list. Each slice is ordered by descending raw score.
+type Merger interface {
+	Rescore(results map[domain.SearchResultType][]domain.SearchResult)
+}
+
+type MergerFunc func(results map[domain.SearchResultType][]domain.SearchResult)
+
+func (f MergerFunc) Rescore(results map[domain.SearchResultType][]domain.SearchResult) {
+	f(results)
+}
+
+var mergers = map[domain.MergeStrategy]Merger{
+	domain.MergeByScore:  MergerFunc(func(map[domain.SearchResultType][]domain.SearchResult) {}),
+	domain.MergeByMinMax: MergerFunc(minMaxRescore),
+	domain.MergeByRRF:    MergerFunc(rrfRescore),
+}
+
+// RegisterMerger makes an additional merge strategy selectable per request.
+func RegisterMerger(strategy domain.MergeStrategy, merger Merger) {
+	mergers[strategy] = merger
+}
+
+func lookupMerger(strategy domain.MergeStrategy) (Merger, error) {
+	if strategy == "" {
+		strategy = domain.MergeByScore
+	}
+	merger, ok := mergers[strategy]
+	if !ok {
+		return nil, domain.ErrUnknownMergeStrategy
+	}
+	return merger, nil
+}
+
+// mergeResults rescores the hits with the given merger, applies the per-type
+// weights and returns one list sorted by the resulting score. Explained hits
+// record the rescored score and the weight.
+func mergeResults(results map[domain.SearchResultType][]domain.SearchResult, merger Merger, weights map[domain.SearchResultType]float64) []domain.SearchResult {
+	merger.Rescore(results)
+
+	merged := make([]domain.SearchResult, 0)
+	for resultType, hits := range results {
+		weight, ok := weights[resultType]
+		if !ok {
+			weight = 1
+		}
+		for _, hit := range hits {
+			if hit.Explanation != nil {
+				hit.Explanation.Normalized = hit.Score
+				hit.Explanation.Weight = weight
+			}
+			hit.Score *= weight
+			merged = append(merged, hit)
+		}
+	}
+
+	// Sort results by score in descending order, keeping the order stable
+	// across requests so that consecutive pages do not overlap
+	sort.SliceStable(merged, func(i, j int) bool {
+		if merged[i].Score != merged[j].Score {
+			return merged[i].Score > merged[j].Score
+		}
+		if merged[i].Type != merged[j].Type {
+			return merged[i].Type < merged[j].Type
+		}
+		return merged[i].ID < merged[j].ID
+	})
+
+	return merged
+}
+
+// minMaxRescore scales the scores of each type into [0, 1] between zero and
+// the best hit of that type. Every type is fetched from its first hit, so the
+// best hit and with it the scaled scores are the same on every page; scaling
+// from the worst hit fetched instead would shift them as the window grows
+// and let consecutive pages repeat or skip results. A type whose best score
+// is zero is scaled to 1.
+func minMaxRescore(results map[domain.SearchResultType][]domain.SearchResult) {
+	for _, hits := range results {
+		highest := 0.0
+		for _, hit := range hits {
+			highest = math.Max(highest, hit.Score)
+		}
+		for i := range hits {
+			if highest == 0 {
+				hits[i].Score = 1
+				continue
+			}
+			hits[i].Score /= highest
+		}
+	}
+}
+
+// rrfRescore replaces each score with 1/(k+rank) of the hit within its type.
+func rrfRescore(results map[domain.SearchResultType][]domain.SearchResult) {
+	for _, hits := range results {
+		for i := range hits {
+			hits[i].Score = 1 / float64(rrfRankConstant+i+1)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	es "github.com/elastic/go-elasticsearch/v8"
+)
+
+// msearchRequest is one search of an _msearch.
+type msearchRequest struct {
+	Index string
+	Body  map[string]interface{}
+}
+
+// msearch runs every search in a single round trip and returns their
+// responses in request order. Each search fails on its own, reported through
+// the Error of its response; the returned error means none of them ran.
+func msearch(ctx context.Context, client *es.Client, requests []msearchRequest) ([]msearchItem, error) {
+	var body bytes.Buffer
+	for _, request := range requests {
+		header, err := json.Marshal(map[string]interface{}{"index": request.Index})
+		if err != nil {
+			return nil, err
+		}
+		query, err := json.Marshal(request.Body)
+		if err != nil {
+			return nil, err
+		}
+		body.Write(header)
+		body.WriteByte('\n')
+		body.Write(query)
+		body.WriteByte('\n')
+	}
+
+	res, err := client.Msearch(
+		&body,
+		client.Msearch.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var result msearchResponse
+	if err := decodeResponse(res, &result); err != nil {
+		return nil, err
+	}
+	if len(result.Responses) != len(requests) {
+		return nil, fmt.Errorf("msearch returned %d responses for %d searches", len(result.Responses), len(requests))
+	}
+	return result.Responses, nil
+}
@@ -3,11 +3,13 @@ package elasticsearch
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	elastic "github.com/elastic/go-elasticsearch/v8"
+	"github.com/elastic/go-elasticsearch/v8/esapi"
 	"github.com/google/uuid"
 	"github.com/oSoloTurk/multiple-kind-search/internal/domain"
 	"github.com/oSoloTurk/multiple-kind-search/internal/logger"
@@ -17,10 +19,13 @@ const newsIndex = "news"
 
 type newsRepository struct {
 	client *elastic.Client
+	alerts domain.AlertRepository
 }
 
-func NewNewsRepository(client *elastic.Client) domain.NewsRepository {
-	return &newsRepository{client: client}
+// NewNewsRepository returns the news repository. Every article written is
+// matched against the saved searches through alerts.
+func NewNewsRepository(client *elastic.Client, alerts domain.AlertRepository) domain.NewsRepository {
+	return &newsRepository{client: client, alerts: alerts}
 }
 
 func (r *newsRepository) Create(news *domain.News) error {
@@ -31,6 +36,13 @@ func (r *newsRepository) Create(news *domain.News) error {
 	news.CreatedAt = now
 	news.UpdatedAt = now
 
+	author, err := r.author(news.AuthorID)
+	if err != nil {
+		return err
+	}
+	news.AuthorName = author.Name
+	news.AuthorImageURL = author.ImageURL
+
 	logger.Logger.Info().
 		Str("id", news.ID).
 		Str("title", news.Title).
@@ -41,12 +53,16 @@ func (r *newsRepository) Create(news *domain.News) error {
 		return err
 	}
 
-	_, err = r.client.Index(
+	res, err := r.client.Index(
 		newsIndex,
 		strings.NewReader(string(body)),
 		r.client.Index.WithDocumentID(news.ID),
 		r.client.Index.WithContext(context.Background()),
 	)
+	if err == nil {
+		defer res.Body.Close()
+		err = decodeResponse(res, nil)
+	}
 	if err != nil {
 		logger.Logger.Error().
 			Err(err).
@@ -55,6 +71,8 @@ func (r *newsRepository) Create(news *domain.News) error {
 		return err
 	}
 
+	r.countArticles(news.AuthorID, 1)
+	r.alert(news, domain.NewsCreatedEvent)
 	return nil
 }
 
@@ -67,28 +85,15 @@ func (r *newsRepository) GetByID(id string) (*domain.News, error) {
 	if err != nil {
 		return nil, err
 	}
+	defer res.Body.Close()
 
-	if res.IsError() {
-		return nil, fmt.Errorf("failed to get news article: %s", res.Status())
-	}
-
-	var result map[string]interface{}
-	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
-		return nil, err
-	}
-
-	source, ok := result["_source"].(map[string]interface{})
-	if !ok {
-		return nil, nil // Document not found
-	}
-
-	sourceBytes, err := json.Marshal(source)
-	if err != nil {
-		return nil, err
+	var result getResponse
+	if err := decodeResponse(res, &result); err != nil {
+		return nil, fmt.Errorf("failed to get news article %s: %w", id, err)
 	}
 
 	var news domain.News
-	if err := json.Unmarshal(sourceBytes, &news); err != nil {
+	if err := decodeSource(result.Source, &news); err != nil {
 		return nil, err
 	}
 
@@ -98,6 +103,17 @@ func (r *newsRepository) GetByID(id string) (*domain.News, error) {
 func (r *newsRepository) Update(news *domain.News) error {
 	news.UpdatedAt = time.Now()
 
+	previous, err := r.GetByID(news.ID)
+	if err != nil {
+		return err
+	}
+	author, err := r.author(news.AuthorID)
+	if err != nil {
+		return err
+	}
+	news.AuthorName = author.Name
+	news.AuthorImageURL = author.ImageURL
+
 	body, err := json.Marshal(map[string]interface{}{
 		"doc": news,
 	})
@@ -105,17 +121,86 @@ func (r *newsRepository) Update(news *domain.News) error {
 		return err
 	}
 
-	_, err = r.client.Update(
+	res, err := r.client.Update(
 		newsIndex,
 		news.ID,
 		strings.NewReader(string(body)),
 		r.client.Update.WithContext(context.Background()),
 	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if err := decodeResponse(res, nil); err != nil {
+		return fmt.Errorf("failed to update news article %s: %w", news.ID, err)
+	}
+	if previous.AuthorID != news.AuthorID {
+		r.countArticles(previous.AuthorID, -1)
+		r.countArticles(news.AuthorID, 1)
+	}
+
+	// The update merges into the stored article, which is what the saved
+	// searches see
+	stored, err := r.GetByID(news.ID)
+	if err != nil {
+		logger.Logger.Warn().
+			Err(err).
+			Str("id", news.ID).
+			Msg("Failed to read updated news article for saved searches")
+		return nil
+	}
+	r.alert(stored, domain.NewsUpdatedEvent)
+	return nil
+}
+
+// alert matches a stored article against the saved searches. The article
+// is written by then, so failing to alert is only logged.
+func (r *newsRepository) alert(news *domain.News, event domain.AlertEvent) {
+	if _, err := r.alerts.Match(news, event); err != nil {
+		logger.Logger.Warn().
+			Err(err).
+			Str("id", news.ID).
+			Str("event", string(event)).
+			Msg("Failed to match saved searches")
+	}
+}
+
+// author returns the name and image of the author of an article, which are
+// copied onto the article so that searching and showing it needs no lookup.
+func (r *newsRepository) author(authorID string) (*domain.Author, error) {
+	res, err := r.client.Get(
+		authorIndex,
+		authorID,
+		r.client.Get.WithSourceIncludes("name", "imageUrl"),
+		r.client.Get.WithContext(context.Background()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
 
-	return err
+	var result getResponse
+	if err := decodeResponse(res, &result); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrNewsAuthorNotFound
+		}
+		return nil, fmt.Errorf("failed to get news author %s: %w", authorID, err)
+	}
+
+	var author domain.Author
+	if err := decodeSource(result.Source, &author); err != nil {
+		return nil, err
+	}
+	return &author, nil
 }
 
 func (r *newsRepository) Delete(id string) error {
+	news, err := r.GetByID(id)
+	if err != nil {
+		return err
+	}
+
 	res, err := r.client.Delete(
 		newsIndex,
 		id,
@@ -126,15 +211,54 @@ func (r *newsRepository) Delete(id string) error {
 	}
 	defer res.Body.Close()
 
+	if err := decodeResponse(res, nil); err != nil {
+		return fmt.Errorf("failed to delete news article %s: %w", id, err)
+	}
+
+	r.countArticles(news.AuthorID, -1)
 	return nil
 }
 
-func (r *newsRepository) List() ([]domain.News, error) {
-	query := map[string]interface{}{
-		"query": map[string]interface{}{
-			"match_all": map[string]interface{}{},
+// articleCountScript adds delta to the article count of an author.
+const articleCountScript = "ctx._source.articleCount = (ctx._source.articleCount == null ? 0 : ctx._source.articleCount) + params.delta"
+
+// countArticles adds delta to the number of news an author wrote, which is
+// kept on the author so that search results show it without a lookup. The
+// article is written by then, so failing to count it is only logged.
+func (r *newsRepository) countArticles(authorID string, delta int) {
+	body, err := json.Marshal(map[string]interface{}{
+		"script": map[string]interface{}{
+			"source": articleCountScript,
+			"params": map[string]interface{}{"delta": delta},
 		},
-		"size": 1000,
+	})
+	if err == nil {
+		var res *esapi.Response
+		res, err = r.client.Update(
+			authorIndex,
+			authorID,
+			strings.NewReader(string(body)),
+			r.client.Update.WithRetryOnConflict(3),
+			r.client.Update.WithContext(context.Background()),
+		)
+		if err == nil {
+			defer res.Body.Close()
+			err = decodeResponse(res, nil)
+		}
+	}
+	if err != nil {
+		logger.Logger.Warn().
+			Err(err).
+			Str("authorId", authorID).
+			Int("delta", delta).
+			Msg("Failed to count author articles")
+	}
+}
+
+func (r *newsRepository) List(filter domain.ListFilter) (*domain.NewsPage, error) {
+	query, err := buildListQuery(filter)
+	if err != nil {
+		return nil, err
 	}
 
 	body, err := json.Marshal(query)
@@ -152,29 +276,111 @@ func (r *newsRepository) List() ([]domain.News, error) {
 	}
 	defer res.Body.Close()
 
-	var result map[string]interface{}
-	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
-		return nil, err
+	var result searchResponse
+	if err := decodeResponse(res, &result); err != nil {
+		return nil, fmt.Errorf("failed to list news: %w", err)
 	}
 
-	hits := result["hits"].(map[string]interface{})["hits"].([]interface{})
-	newsList := make([]domain.News, 0)
+	hits := result.Hits.Hits
+	page := &domain.NewsPage{Items: make([]domain.News, 0, len(hits))}
 
-	for _, hit := range hits {
-		hitMap := hit.(map[string]interface{})
-		source := hitMap["_source"]
+	for i, hit := range hits {
+		if i == filter.Size {
+			// The extra hit only signals that another page follows
+			page.Next = encodeCursor(hits[i-1].Sort)
+			break
+		}
 
-		sourceBytes, err := json.Marshal(source)
-		if err != nil {
+		var news domain.News
+		if err := decodeSource(hit.Source, &news); err != nil {
 			return nil, err
 		}
+		page.Items = append(page.Items, news)
+	}
+
+	return page, nil
+}
+
+// Related returns the news most similar to an article by title, content and
+// tags, leaving the article itself out.
+func (r *newsRepository) Related(id string, filter domain.RelatedFilter) ([]domain.News, error) {
+	news, err := r.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
 
+	filters := make([]interface{}, 0)
+	if filter.SameAuthor {
+		filters = append(filters, map[string]interface{}{
+			"term": map[string]interface{}{
+				"authorID": news.AuthorID,
+			},
+		})
+	}
+	if filter.SameTags {
+		if len(news.Tags) == 0 {
+			return make([]domain.News, 0), nil
+		}
+		filters = append(filters, map[string]interface{}{
+			"terms": map[string]interface{}{
+				"tags": news.Tags,
+			},
+		})
+	}
+
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"must": map[string]interface{}{
+					"more_like_this": map[string]interface{}{
+						"fields": []string{"title", "content", "tags"},
+						"like": []interface{}{
+							map[string]interface{}{"_index": newsIndex, "_id": id},
+						},
+						// The collection is small, so rare terms still count
+						"min_term_freq":   1,
+						"min_doc_freq":    1,
+						"max_query_terms": 25,
+					},
+				},
+				"must_not": map[string]interface{}{
+					"ids": map[string]interface{}{
+						"values": []string{id},
+					},
+				},
+				"filter": filters,
+			},
+		},
+		"size": filter.Size,
+	}
+
+	body, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := r.client.Search(
+		r.client.Search.WithIndex(newsIndex),
+		r.client.Search.WithBody(strings.NewReader(string(body))),
+		r.client.Search.WithContext(context.Background()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var result searchResponse
+	if err := decodeResponse(res, &result); err != nil {
+		return nil, fmt.Errorf("failed to find news related to %s: %w", id, err)
+	}
+
+	related := make([]domain.News, 0, len(result.Hits.Hits))
+	for _, hit := range result.Hits.Hits {
 		var news domain.News
-		if err := json.Unmarshal(sourceBytes, &news); err != nil {
+		if err := decodeSource(hit.Source, &news); err != nil {
 			return nil, err
 		}
-		newsList = append(newsList, news)
+		related = append(related, news)
 	}
-
-	return newsList, nil
+	return related, nil
 }
@@ -0,0 +1,433 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/oSoloTurk/multiple-kind-search/internal/domain"
+)
+
+type newsKind struct{}
+
+// NewNewsKind makes news searchable by title and content, boosting articles
+// written by the filter's preferred authors.
+func NewNewsKind() SearchableKind {
+	return &newsKind{}
+}
+
+func (k *newsKind) Type() domain.SearchResultType {
+	return domain.NewsResultType
+}
+
+func (k *newsKind) Index() string {
+	return newsIndex
+}
+
+func (k *newsKind) Weight() float64 {
+	return 1
+}
+
+func (k *newsKind) Query(ctx context.Context, filter domain.SearchFilter) (map[string]interface{}, error) {
+	query := map[string]interface{}{
+		"query":     newsQuery(filter),
+		"highlight": highlightQuery(languageFields("title", filter.Language), languageFields("content", filter.Language), filter),
+	}
+	if filter.Facets {
+		// Facet selections narrow the hits after the aggregations counted
+		// them, see Aggregations
+		query["post_filter"] = facetFiltersExcept(newsFacetFilters(filter), "")
+	}
+
+	return query, nil
+}
+
+// newsQuery matches and scores the news the filter searches for.
+func newsQuery(filter domain.SearchFilter) map[string]interface{} {
+	// Authors given by name match the copy of the name on each article,
+	// which the keyword normalizer compares ignoring case
+	boosts := make([]interface{}, 0, len(filter.Boosts))
+	for _, boost := range filter.Boosts {
+		field, value := "authorID", boost.ID
+		if boost.ID == "" {
+			field, value = "authorName", boost.Name
+		}
+		boosts = append(boosts, map[string]interface{}{
+			"term": map[string]interface{}{
+				field: map[string]interface{}{
+					"value": value,
+					"boost": boost.Weight,
+				},
+			},
+		})
+	}
+
+	return recencyScore(map[string]interface{}{
+		"bool": map[string]interface{}{
+			"must":   userQuery(filter.Parsed, textFields(filter.Language, "title", "content"), filter.Fuzziness, newsQualifier(filter.Language)),
+			"should": boosts,
+			"filter": newsFilters(filter),
+		},
+	}, filter.NewsRecency())
+}
+
+// newsQualifier matches the title, content, tag and author qualifiers, the
+// text ones through the fields of the language. Tags and author names match
+// in any case through the normalizers of their fields.
+func newsQualifier(language domain.Language) qualifierQuery {
+	return func(clause domain.QueryClause) (map[string]interface{}, bool) {
+		switch clause.Field {
+		case domain.TitleQualifier:
+			return fieldQuery(languageFields("title", language), clause), true
+		case domain.ContentQualifier:
+			return fieldQuery(languageFields("content", language), clause), true
+		case domain.TagQualifier:
+			return map[string]interface{}{
+				"term": map[string]interface{}{
+					"tags": clause.Text,
+				},
+			}, true
+		case domain.AuthorQualifier:
+			return map[string]interface{}{
+				"term": map[string]interface{}{
+					"authorName": clause.Text,
+				},
+			}, true
+		default:
+			return nil, false
+		}
+	}
+}
+
+// recencyScore multiplies the score of query by the decay of the age of
+// createdAt, leaving the query as it is when recency is disabled.
+func recencyScore(query map[string]interface{}, recency domain.Recency) map[string]interface{} {
+	if !recency.Enabled() {
+		return query
+	}
+	return map[string]interface{}{
+		"function_score": map[string]interface{}{
+			"query": query,
+			"functions": []interface{}{
+				map[string]interface{}{
+					string(recency.Function): map[string]interface{}{
+						"createdAt": map[string]interface{}{
+							"origin": "now",
+							"scale":  fmt.Sprintf("%dms", recency.Scale.Milliseconds()),
+							"offset": fmt.Sprintf("%dms", recency.Offset.Milliseconds()),
+							"decay":  recency.Decay,
+						},
+					},
+				},
+			},
+			"boost_mode": "multiply",
+		},
+	}
+}
+
+// newsFilters turns the filters into non-scoring filter clauses. The facet
+// selections are among them unless facets are requested, in which case they
+// go to newsPostFilter instead so that each facet can still count its other
+// values.
+func newsFilters(filter domain.SearchFilter) []interface{} {
+	filters := make([]interface{}, 0)
+	if !filter.Facets {
+		for _, facet := range newsFacets {
+			filters = append(filters, newsFacetFilters(filter)[facet]...)
+		}
+	}
+	if filter.Language != "" {
+		filters = append(filters, languageFilter(filter.Language))
+	}
+	if !filter.CreatedAt.IsZero() {
+		filters = append(filters, dateRangeFilter("createdAt", filter.CreatedAt))
+	}
+	if !filter.UpdatedAt.IsZero() {
+		filters = append(filters, dateRangeFilter("updatedAt", filter.UpdatedAt))
+	}
+	return filters
+}
+
+// newsFacets are the facets of news, in the order their filters apply.
+var newsFacets = []string{domain.TagFacet, domain.AuthorFacet, domain.MonthFacet}
+
+// newsFacetFilters returns the filter clauses of the selected values of each
+// facet. Tags match in any case, as the tag qualifier does, since the tags
+// field and the terms looked up in it are lowercased by its normalizer.
+func newsFacetFilters(filter domain.SearchFilter) map[string][]interface{} {
+	filters := make(map[string][]interface{}, len(newsFacets))
+	if len(filter.Tags) > 0 && filter.TagMatch == domain.TagMatchAll {
+		for _, tag := range filter.Tags {
+			filters[domain.TagFacet] = append(filters[domain.TagFacet], map[string]interface{}{
+				"term": map[string]interface{}{
+					"tags": tag,
+				},
+			})
+		}
+	} else if len(filter.Tags) > 0 {
+		filters[domain.TagFacet] = append(filters[domain.TagFacet], map[string]interface{}{
+			"terms": map[string]interface{}{
+				"tags": filter.Tags,
+			},
+		})
+	}
+	if len(filter.AuthorIDs) > 0 {
+		filters[domain.AuthorFacet] = append(filters[domain.AuthorFacet], map[string]interface{}{
+			"terms": map[string]interface{}{
+				"authorID": filter.AuthorIDs,
+			},
+		})
+	}
+	if len(filter.Months) > 0 {
+		months := make([]interface{}, 0, len(filter.Months))
+		for _, month := range filter.Months {
+			months = append(months, map[string]interface{}{
+				"range": map[string]interface{}{
+					"createdAt": map[string]interface{}{
+						"gte":    month,
+						"lt":     month + "||+1M",
+						"format": "yyyy-MM",
+					},
+				},
+			})
+		}
+		filters[domain.MonthFacet] = append(filters[domain.MonthFacet], map[string]interface{}{
+			"bool": map[string]interface{}{
+				"should":               months,
+				"minimum_should_match": 1,
+			},
+		})
+	}
+	return filters
+}
+
+// facetFiltersExcept combines the filters of every facet but one, or of
+// every facet when except is empty.
+func facetFiltersExcept(filters map[string][]interface{}, except string) map[string]interface{} {
+	combined := make([]interface{}, 0)
+	for _, facet := range newsFacets {
+		if facet != except {
+			combined = append(combined, filters[facet]...)
+		}
+	}
+	return map[string]interface{}{
+		"bool": map[string]interface{}{
+			"filter": combined,
+		},
+	}
+}
+
+// esDateLayout formats times at the millisecond precision of date fields.
+const esDateLayout = "2006-01-02T15:04:05.000Z07:00"
+
+func dateRangeFilter(field string, dateRange domain.DateRange) map[string]interface{} {
+	bounds := make(map[string]interface{})
+	if dateRange.From != nil {
+		bounds["gte"] = dateRange.From.Format(esDateLayout)
+	}
+	if dateRange.To != nil {
+		bounds["lt"] = dateRange.To.Format(esDateLayout)
+	}
+	return map[string]interface{}{
+		"range": map[string]interface{}{
+			field: bounds,
+		},
+	}
+}
+
+// Aggregations counts tags in their lowercased form, the one the tag filter
+// and suggestions use, authors by ID and months. Each facet counts the hits
+// matching every facet selection but its own, so selecting a value leaves
+// the other values of its facet to choose from. Tags required all together
+// count only the hits carrying the selected ones.
+func (k *newsKind) Aggregations(filter domain.SearchFilter) map[string]interface{} {
+	aggregations := map[string]interface{}{
+		domain.TagFacet: map[string]interface{}{
+			"terms": map[string]interface{}{
+				"field": "tags",
+				"size":  facetSize,
+			},
+		},
+		domain.AuthorFacet: map[string]interface{}{
+			"terms": map[string]interface{}{
+				"field": "authorID",
+				"size":  facetSize,
+			},
+			"aggs": map[string]interface{}{
+				"name": map[string]interface{}{
+					"top_hits": map[string]interface{}{
+						"size":    1,
+						"_source": []string{"authorName"},
+					},
+				},
+			},
+		},
+		domain.MonthFacet: map[string]interface{}{
+			"date_histogram": map[string]interface{}{
+				"field":             "createdAt",
+				"calendar_interval": "month",
+				"format":            "yyyy-MM",
+				"min_doc_count":     1,
+				"order":             map[string]interface{}{"_key": "desc"},
+			},
+		},
+	}
+
+	selected := newsFacetFilters(filter)
+	for facet, aggregation := range aggregations {
+		except := facet
+		if facet == domain.TagFacet && filter.TagMatch == domain.TagMatchAll {
+			// Requiring every tag, selecting another one narrows the hits
+			except = ""
+		}
+		aggregations[facet] = map[string]interface{}{
+			"filter": facetFiltersExcept(selected, except),
+			"aggs":   map[string]interface{}{facetValues: aggregation},
+		}
+	}
+	return aggregations
+}
+
+// Facets returns the tag, author and month facets, labelling author buckets
+// with the author name copied onto their articles.
+func (k *newsKind) Facets(ctx context.Context, aggregations map[string]json.RawMessage) (map[string][]domain.FacetBucket, error) {
+	aggregations, err := unwrapFacets(aggregations)
+	if err != nil {
+		return nil, err
+	}
+
+	facets := map[string][]domain.FacetBucket{
+		domain.TagFacet:    GetBuckets(aggregations, domain.TagFacet),
+		domain.AuthorFacet: make([]domain.FacetBucket, 0),
+		domain.MonthFacet:  GetBuckets(aggregations, domain.MonthFacet),
+	}
+
+	var authors struct {
+		Buckets []struct {
+			aggregationBucket
+			Name struct {
+				Hits searchHits `json:"hits"`
+			} `json:"name"`
+		} `json:"buckets"`
+	}
+	if raw, ok := aggregations[domain.AuthorFacet]; ok {
+		if err := json.Unmarshal(raw, &authors); err != nil {
+			return facets, err
+		}
+	}
+	for _, bucket := range authors.Buckets {
+		var news domain.News
+		if hits := bucket.Name.Hits.Hits; len(hits) > 0 {
+			_ = decodeSource(hits[0].Source, &news)
+		}
+		facets[domain.AuthorFacet] = append(facets[domain.AuthorFacet], domain.FacetBucket{
+			Key:   bucket.bucketKey(),
+			Label: news.AuthorName,
+			Count: bucket.DocCount,
+		})
+	}
+
+	return facets, nil
+}
+
+func (k *newsKind) MapHit(hit searchHit) (domain.SearchResult, error) {
+	var news domain.News
+	if err := decodeSource(hit.Source, &news); err != nil {
+		return domain.SearchResult{}, err
+	}
+
+	return domain.SearchResult{
+		ID:         news.ID,
+		Title:      news.Title,
+		Content:    news.Content,
+		Highlights: GetHighlights(hit, languageFields("title", ""), languageFields("content", "")),
+		Score:      hit.Score,
+		Type:       domain.NewsResultType,
+		ImageURL:   news.ImageURL,
+		Author: &domain.ResultAuthor{
+			ID:       news.AuthorID,
+			Name:     news.AuthorName,
+			ImageURL: news.AuthorImageURL,
+		},
+		CreatedAt: &news.CreatedAt,
+		Tags:      news.Tags,
+	}, nil
+}
+
+// SuggestQuery completes news titles and, through a global aggregation that
+// ignores the title query, tags starting with the prefix. Tags are indexed
+// lowercased by their normalizer, so the prefix is lowercased to match them
+// in any case.
+func (k *newsKind) SuggestQuery(prefix string, size int) map[string]interface{} {
+	tagPrefix := strings.ToLower(prefix)
+	return map[string]interface{}{
+		"size":    size,
+		"_source": []string{"id", "title"},
+		"query": map[string]interface{}{
+			"multi_match": map[string]interface{}{
+				"query":  prefix,
+				"type":   "bool_prefix",
+				"fields": searchAsYouTypeFields("titleSuggest"),
+			},
+		},
+		"aggs": map[string]interface{}{
+			"tags": map[string]interface{}{
+				"global": map[string]interface{}{},
+				"aggs": map[string]interface{}{
+					"matching": map[string]interface{}{
+						"filter": map[string]interface{}{
+							"prefix": map[string]interface{}{
+								"tags": tagPrefix,
+							},
+						},
+						"aggs": map[string]interface{}{
+							"values": map[string]interface{}{
+								"terms": map[string]interface{}{
+									"field":   "tags",
+									"include": escapeRegexp(tagPrefix) + ".*",
+									"size":    size,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (k *newsKind) MapSuggestions(result *searchResponse) []domain.Suggestion {
+	suggestions := make([]domain.Suggestion, 0, len(result.Hits.Hits))
+	for _, hit := range result.Hits.Hits {
+		var news domain.News
+		if err := decodeSource(hit.Source, &news); err != nil {
+			continue
+		}
+		suggestions = append(suggestions, domain.Suggestion{
+			Text: news.Title,
+			Type: domain.NewsResultType,
+			ID:   news.ID,
+		})
+	}
+
+	var tags struct {
+		Matching struct {
+			Values bucketAggregation `json:"values"`
+		} `json:"matching"`
+	}
+	if raw, ok := result.Aggregations["tags"]; ok {
+		_ = json.Unmarshal(raw, &tags)
+	}
+	for _, bucket := range tags.Matching.Values.facetBuckets() {
+		suggestions = append(suggestions, domain.Suggestion{
+			Text: bucket.Key,
+			Type: domain.TagResultType,
+		})
+	}
+	return suggestions
+}
+
+func (k *newsKind) SpellcheckFields() []string {
+	return []string{"content", "title"}
+}
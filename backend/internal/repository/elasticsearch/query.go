@@ -0,0 +1,192 @@
+package elasticsearch
+
+import "github.com/oSoloTurk/multiple-kind-search/internal/domain"
+
+// fuzzyMatchBoost weighs fuzzy matches below exact ones, so a document
+// matching the query as typed always outranks one that only matches with typos.
+const fuzzyMatchBoost = 0.5
+
+// textQuery matches the query against the fields of a kind. With fuzziness
+// enabled, exact and fuzzy matches are both accepted, the exact clause adding
+// to the score of documents that match as typed.
+func textQuery(query string, fields []string, fuzziness domain.Fuzziness) map[string]interface{} {
+	exact := map[string]interface{}{
+		"multi_match": map[string]interface{}{
+			"query":       query,
+			"fields":      fields,
+			"type":        "best_fields",
+			"tie_breaker": 0.3,
+		},
+	}
+	if !fuzziness.Enabled() {
+		return exact
+	}
+
+	fuzzy := map[string]interface{}{
+		"query":         query,
+		"fields":        fields,
+		"type":          "best_fields",
+		"tie_breaker":   0.3,
+		"fuzziness":     fuzziness.Distance,
+		"prefix_length": fuzziness.PrefixLength,
+		"boost":         fuzzyMatchBoost,
+	}
+	if fuzziness.MaxExpansions > 0 {
+		fuzzy["max_expansions"] = fuzziness.MaxExpansions
+	}
+
+	return map[string]interface{}{
+		"bool": map[string]interface{}{
+			"should": []interface{}{
+				exact,
+				map[string]interface{}{"multi_match": fuzzy},
+			},
+			"minimum_should_match": 1,
+		},
+	}
+}
+
+// qualifierQuery translates a qualifier clause for a kind, reporting false
+// when the kind has nothing the qualifier could match.
+type qualifierQuery func(clause domain.QueryClause) (map[string]interface{}, bool)
+
+// userQuery matches a parsed query against the text fields of a kind. Free
+// text terms are matched together by textQuery, so documents containing more
+// of them rank higher, while phrases, qualifiers and OR groups must all match
+// and negated clauses exclude documents.
+func userQuery(query *domain.ParsedQuery, fields []string, fuzziness domain.Fuzziness, qualifier qualifierQuery) map[string]interface{} {
+	must := make([]interface{}, 0)
+	mustNot := make([]interface{}, 0)
+
+	if text := query.FreeText(); text != "" {
+		must = append(must, textQuery(text, fields, fuzziness))
+	}
+	for _, clause := range query.Clauses {
+		if clause.Type == domain.TermClause && !clause.Negated {
+			continue
+		}
+
+		translated, ok := clauseQuery(clause, fields, qualifier)
+		switch {
+		case clause.Negated && ok:
+			mustNot = append(mustNot, translated)
+		case clause.Negated:
+			// Nothing of this kind can match, so nothing is excluded
+		case ok:
+			must = append(must, translated)
+		default:
+			must = append(must, matchNone())
+		}
+	}
+	if len(must) == 0 {
+		must = append(must, map[string]interface{}{"match_all": map[string]interface{}{}})
+	}
+
+	return map[string]interface{}{
+		"bool": map[string]interface{}{
+			"must":     must,
+			"must_not": mustNot,
+		},
+	}
+}
+
+// clauseQuery translates a clause regardless of its negation, reporting
+// false when it cannot match anything of the kind.
+func clauseQuery(clause domain.QueryClause, fields []string, qualifier qualifierQuery) (map[string]interface{}, bool) {
+	switch clause.Type {
+	case domain.TermClause:
+		return map[string]interface{}{
+			"multi_match": map[string]interface{}{
+				"query":  clause.Text,
+				"fields": fields,
+			},
+		}, true
+	case domain.PhraseClause:
+		return map[string]interface{}{
+			"multi_match": map[string]interface{}{
+				"query":  clause.Text,
+				"fields": fields,
+				"type":   "phrase",
+			},
+		}, true
+	case domain.FieldClause:
+		return qualifier(clause)
+	case domain.OrClause:
+		alternatives := make([]interface{}, 0, len(clause.Any))
+		for _, alternative := range clause.Any {
+			translated, ok := clauseQuery(alternative, fields, qualifier)
+			switch {
+			case alternative.Negated && ok:
+				alternatives = append(alternatives, map[string]interface{}{
+					"bool": map[string]interface{}{
+						"must_not": translated,
+					},
+				})
+			case alternative.Negated:
+				// Excluding what cannot match leaves every document
+				alternatives = append(alternatives, map[string]interface{}{"match_all": map[string]interface{}{}})
+			case ok:
+				alternatives = append(alternatives, translated)
+			}
+		}
+		if len(alternatives) == 0 {
+			return nil, false
+		}
+		return map[string]interface{}{
+			"bool": map[string]interface{}{
+				"should":               alternatives,
+				"minimum_should_match": 1,
+			},
+		}, true
+	default:
+		return nil, false
+	}
+}
+
+// fieldQuery matches a qualifier value against the fields of one text
+// field, word for word when the value was quoted.
+func fieldQuery(fields []string, clause domain.QueryClause) map[string]interface{} {
+	query := map[string]interface{}{
+		"query":  clause.Text,
+		"fields": fields,
+	}
+	if clause.Phrase {
+		query["type"] = "phrase"
+	}
+	return map[string]interface{}{"multi_match": query}
+}
+
+// languageFields returns the fields a text field is searched through for a
+// language: the subfield analyzed for that language, or with no language the
+// field itself and the subfield of every language.
+func languageFields(field string, language domain.Language) []string {
+	if language != "" {
+		return []string{field + "." + string(language)}
+	}
+	fields := []string{field}
+	for _, language := range domain.Languages {
+		fields = append(fields, field+"."+string(language))
+	}
+	return fields
+}
+
+// languageFilter keeps the documents written in a language, as detected or
+// given when they were stored.
+func languageFilter(language domain.Language) map[string]interface{} {
+	return map[string]interface{}{
+		"term": map[string]interface{}{"language": language},
+	}
+}
+
+// textFields returns the languageFields of several text fields.
+func textFields(language domain.Language, fields ...string) []string {
+	all := make([]string, 0, len(fields)*(len(domain.Languages)+1))
+	for _, field := range fields {
+		all = append(all, languageFields(field, language)...)
+	}
+	return all
+}
+
+func matchNone() map[string]interface{} {
+	return map[string]interface{}{"match_none": map[string]interface{}{}}
+}
@@ -0,0 +1,125 @@
+package elasticsearch
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+	"github.com/oSoloTurk/multiple-kind-search/internal/domain"
+)
+
+// Typed views of the Elasticsearch responses read by the repositories. Only
+// the fields in use are declared, and document sources are kept raw so each
+// is decoded once, straight into its domain type.
+
+type searchResponse struct {
+	TimedOut     bool                       `json:"timed_out"`
+	Hits         searchHits                 `json:"hits"`
+	Aggregations map[string]json.RawMessage `json:"aggregations"`
+	Suggest      map[string][]suggestEntry  `json:"suggest"`
+}
+
+type searchHits struct {
+	Total struct {
+		Value int64 `json:"value"`
+	} `json:"total"`
+	Hits []searchHit `json:"hits"`
+}
+
+type searchHit struct {
+	ID             string              `json:"_id"`
+	Score          float64             `json:"_score"`
+	Source         json.RawMessage     `json:"_source"`
+	Highlight      map[string][]string `json:"highlight"`
+	Sort           json.RawMessage     `json:"sort"`
+	MatchedQueries []string            `json:"matched_queries"`
+	Explanation    *explanation        `json:"_explanation"`
+}
+
+type suggestEntry struct {
+	Options []suggestOption `json:"options"`
+}
+
+type suggestOption struct {
+	Text string `json:"text"`
+}
+
+// bucketAggregation is the result of a terms or histogram aggregation.
+type bucketAggregation struct {
+	Buckets []aggregationBucket `json:"buckets"`
+}
+
+type aggregationBucket struct {
+	Key         json.RawMessage `json:"key"`
+	KeyAsString string          `json:"key_as_string"`
+	DocCount    int64           `json:"doc_count"`
+}
+
+type getResponse struct {
+	ID     string          `json:"_id"`
+	Found  bool            `json:"found"`
+	Source json.RawMessage `json:"_source"`
+}
+
+type mgetResponse struct {
+	Docs []getResponse `json:"docs"`
+}
+
+type msearchResponse struct {
+	Responses []msearchItem `json:"responses"`
+}
+
+// msearchItem is the response to one search of an _msearch, which fails on
+// its own with Error set.
+type msearchItem struct {
+	searchResponse
+	Status int         `json:"status"`
+	Error  *errorCause `json:"error"`
+}
+
+type errorResponse struct {
+	Error  errorCause `json:"error"`
+	Status int        `json:"status"`
+}
+
+type errorCause struct {
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
+
+func (c errorCause) Error() string {
+	return fmt.Sprintf("%s: %s", c.Type, c.Reason)
+}
+
+// decodeResponse decodes the body of a successful response into v. Error
+// responses are returned as errors, wrapping domain.ErrNotFound or
+// domain.ErrUnavailable when the status calls for it.
+func decodeResponse(res *esapi.Response, v interface{}) error {
+	if res.IsError() {
+		return responseError(res)
+	}
+	if v == nil {
+		return nil
+	}
+	return json.NewDecoder(res.Body).Decode(v)
+}
+
+func responseError(res *esapi.Response) error {
+	reason := res.Status()
+	var body errorResponse
+	if err := json.NewDecoder(res.Body).Decode(&body); err == nil && body.Error.Type != "" {
+		reason = body.Error.Error()
+	}
+
+	switch res.StatusCode {
+	case http.StatusNotFound:
+		return fmt.Errorf("%w: %s", domain.ErrNotFound, reason)
+	case http.StatusConflict:
+		return fmt.Errorf("%w: %s", domain.ErrConflict, reason)
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return fmt.Errorf("%w: %s", domain.ErrUnavailable, reason)
+	default:
+		return fmt.Errorf("elasticsearch: %s", reason)
+	}
+}
@@ -0,0 +1,273 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/oSoloTurk/multiple-kind-search/internal/domain"
+)
+
+// msearchPayload builds an _msearch response like the one a faceted search
+// of news and authors receives: a page of hits per kind with highlights in
+// the language fields, and the news facets wrapped in their filter
+// aggregations.
+func msearchPayload(hitsPerKind int) []byte {
+	var b strings.Builder
+	b.WriteString(`{"took":12,"responses":[`)
+
+	fmt.Fprintf(&b, `{"took":6,"timed_out":false,"_shards":{"total":1,"successful":1,"skipped":0,"failed":0},`+
+		`"hits":{"total":{"value":%d,"relation":"eq"},"max_score":7.5,"hits":[`, hitsPerKind*10)
+	for i := 0; i < hitsPerKind; i++ {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		fmt.Fprintf(&b, `{"_index":%q,"_id":"news-%d","_score":%g,`+
+			`"_source":{"id":"news-%d","title":"The Future of Cloud Computing %d","content":%q,`+
+			`"authorID":"author-%d","authorName":"Jane Smith","tags":["cloud","ai","iot"],`+
+			`"language":"en","createdAt":"2024-03-01T10:00:00Z","updatedAt":"2024-03-02T10:00:00Z"},`+
+			`"highlight":{"title.en":["The Future of %s Computing %d"],`+
+			`"content.en":["%s providers keep growing","hybrid %s setups in Türkiye"]},`+
+			`"matched_queries":["title","content"]}`,
+			newsIndex, i, 7.5-float64(i)*0.1,
+			i, i, strings.Repeat("Cloud platforms change how teams build software. ", 8),
+			i%5, mark("Cloud"), i, mark("Cloud"), mark("cloud"))
+	}
+	b.WriteString(`]},"aggregations":{` +
+		`"tag":{"doc_count":120,"values":{"doc_count_error_upper_bound":0,"sum_other_doc_count":0,"buckets":[` +
+		`{"key":"cloud","doc_count":42},{"key":"ai","doc_count":17},{"key":"iot","doc_count":9}]}},` +
+		`"author":{"doc_count":120,"values":{"doc_count_error_upper_bound":0,"sum_other_doc_count":0,"buckets":[` +
+		`{"key":"author-0","doc_count":30,"name":{"hits":{"total":{"value":30,"relation":"eq"},"max_score":1,` +
+		`"hits":[{"_index":"news","_id":"news-0","_score":1,"_source":{"authorName":"Jane Smith"}}]}}}]}},` +
+		`"month":{"doc_count":120,"values":{"buckets":[` +
+		`{"key_as_string":"2024-03","key":1709251200000,"doc_count":30},` +
+		`{"key_as_string":"2024-02","key":1706745600000,"doc_count":12}]}}},"status":200},`)
+
+	fmt.Fprintf(&b, `{"took":4,"timed_out":false,"_shards":{"total":1,"successful":1,"skipped":0,"failed":0},`+
+		`"hits":{"total":{"value":%d,"relation":"eq"},"max_score":5.2,"hits":[`, hitsPerKind*3)
+	for i := 0; i < hitsPerKind; i++ {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		fmt.Fprintf(&b, `{"_index":%q,"_id":"author-%d","_score":%g,`+
+			`"_source":{"id":"author-%d","name":"Jane Smith %d","bio":"Writes about cloud computing and distributed systems.",`+
+			`"language":"en","createdAt":"2024-01-01T10:00:00Z","updatedAt":"2024-01-02T10:00:00Z","articleCount":%d},`+
+			`"highlight":{"bio.en":["Writes about %s computing"]}}`,
+			authorIndex, i, 5.2-float64(i)*0.1, i, i, i+3, mark("cloud"))
+	}
+	b.WriteString(`]},"status":200}]}`)
+	return []byte(b.String())
+}
+
+// mark wraps a term in the highlight markers.
+func mark(term string) string {
+	return string(highlightStart) + term + string(highlightEnd)
+}
+
+// mapGeneric maps an _msearch response the way the repository did before
+// responses were typed: decoding it into generic maps and marshalling each
+// source again to decode it into its domain type.
+func mapGeneric(payload []byte) ([]domain.SearchResult, map[string][]domain.FacetBucket, error) {
+	var result map[string]interface{}
+	if err := json.Unmarshal(payload, &result); err != nil {
+		return nil, nil, err
+	}
+
+	results := make([]domain.SearchResult, 0)
+	facets := make(map[string][]domain.FacetBucket)
+	for _, response := range result["responses"].([]interface{}) {
+		response := response.(map[string]interface{})
+		for _, hit := range response["hits"].(map[string]interface{})["hits"].([]interface{}) {
+			hit := hit.(map[string]interface{})
+			source, err := json.Marshal(hit["_source"])
+			if err != nil {
+				return nil, nil, err
+			}
+			highlight, _ := hit["highlight"].(map[string]interface{})
+
+			var mapped domain.SearchResult
+			if hit["_index"] == newsIndex {
+				var news domain.News
+				if err := json.Unmarshal(source, &news); err != nil {
+					return nil, nil, err
+				}
+				mapped = domain.SearchResult{
+					ID:         news.ID,
+					Title:      news.Title,
+					Content:    news.Content,
+					Highlights: genericHighlights(highlight, languageFields("title", ""), languageFields("content", "")),
+					Type:       domain.NewsResultType,
+					ImageURL:   news.ImageURL,
+					Author: &domain.ResultAuthor{
+						ID:       news.AuthorID,
+						Name:     news.AuthorName,
+						ImageURL: news.AuthorImageURL,
+					},
+					CreatedAt: &news.CreatedAt,
+					Tags:      news.Tags,
+				}
+			} else {
+				var author authorDocument
+				if err := json.Unmarshal(source, &author); err != nil {
+					return nil, nil, err
+				}
+				mapped = domain.SearchResult{
+					ID:           author.ID,
+					Title:        author.Name,
+					Content:      author.Bio,
+					Highlights:   genericHighlights(highlight, languageFields("name", ""), languageFields("bio", "")),
+					Type:         domain.AuthorResultType,
+					ImageURL:     author.ImageURL,
+					ArticleCount: &author.ArticleCount,
+				}
+			}
+			mapped.Score = hit["_score"].(float64)
+			results = append(results, mapped)
+		}
+
+		aggregations, _ := response["aggregations"].(map[string]interface{})
+		for name, aggregation := range aggregations {
+			values := aggregation.(map[string]interface{})[facetValues].(map[string]interface{})
+			buckets := make([]domain.FacetBucket, 0)
+			for _, bucket := range values["buckets"].([]interface{}) {
+				bucket := bucket.(map[string]interface{})
+				key, ok := bucket["key_as_string"].(string)
+				if !ok {
+					key = fmt.Sprint(bucket["key"])
+				}
+				var label string
+				if top, ok := bucket["name"].(map[string]interface{}); ok {
+					hits := top["hits"].(map[string]interface{})["hits"].([]interface{})
+					source := hits[0].(map[string]interface{})["_source"].(map[string]interface{})
+					label, _ = source["authorName"].(string)
+				}
+				buckets = append(buckets, domain.FacetBucket{
+					Key:   key,
+					Label: label,
+					Count: int64(bucket["doc_count"].(float64)),
+				})
+			}
+			facets[name] = buckets
+		}
+	}
+	return results, facets, nil
+}
+
+func genericHighlights(highlight map[string]interface{}, titleFields, contentFields []string) map[string][]domain.HighlightFragment {
+	highlights := make(map[string][]domain.HighlightFragment)
+	for resultField, fields := range map[string][]string{
+		domain.TitleHighlight:   titleFields,
+		domain.ContentHighlight: contentFields,
+	} {
+		for _, field := range fields {
+			values, _ := highlight[field].([]interface{})
+			if len(values) == 0 {
+				continue
+			}
+			fragments := make([]domain.HighlightFragment, 0, len(values))
+			for _, value := range values {
+				fragments = append(fragments, parseFragment(value.(string)))
+			}
+			highlights[resultField] = fragments
+			break
+		}
+	}
+	if len(highlights) == 0 {
+		return nil
+	}
+	return highlights
+}
+
+// mapTyped maps an _msearch response as the repository does, decoding it
+// into the typed response and each hit through its kind.
+func mapTyped(payload []byte, kinds []SearchableKind) ([]domain.SearchResult, map[string][]domain.FacetBucket, error) {
+	var result msearchResponse
+	if err := json.Unmarshal(payload, &result); err != nil {
+		return nil, nil, err
+	}
+
+	results := make([]domain.SearchResult, 0)
+	facets := make(map[string][]domain.FacetBucket)
+	for i, kind := range kinds {
+		response := &result.Responses[i]
+		for _, hit := range response.Hits.Hits {
+			mapped, err := kind.MapHit(hit)
+			if err != nil {
+				return nil, nil, err
+			}
+			results = append(results, mapped)
+		}
+
+		faceted, ok := kind.(FacetedKind)
+		if !ok || response.Aggregations == nil {
+			continue
+		}
+		kindFacets, err := faceted.Facets(context.Background(), response.Aggregations)
+		if err != nil {
+			return nil, nil, err
+		}
+		for name, buckets := range kindFacets {
+			facets[name] = buckets
+		}
+	}
+	return results, facets, nil
+}
+
+func TestMapTypedMatchesGeneric(t *testing.T) {
+	payload := msearchPayload(3)
+	kinds := []SearchableKind{NewNewsKind(), NewAuthorKind()}
+
+	wantResults, wantFacets, err := mapGeneric(payload)
+	if err != nil {
+		t.Fatalf("mapGeneric: %v", err)
+	}
+	results, facets, err := mapTyped(payload, kinds)
+	if err != nil {
+		t.Fatalf("mapTyped: %v", err)
+	}
+
+	if !reflect.DeepEqual(results, wantResults) {
+		t.Errorf("results differ:\n got %+v\nwant %+v", results, wantResults)
+	}
+	if !reflect.DeepEqual(facets, wantFacets) {
+		t.Errorf("facets differ:\n got %+v\nwant %+v", facets, wantFacets)
+	}
+	got := results[0].Highlights[domain.ContentHighlight][1]
+	want := domain.HighlightFragment{
+		Text:    "hybrid cloud setups in Türkiye",
+		Matches: []domain.HighlightSpan{{Start: 7, End: 12}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("content highlight = %+v, want %+v", got, want)
+	}
+}
+
+// Both benchmarks map a page of hits and the facets of each kind, so the
+// difference is what typed responses save end to end: sources decoded once
+// straight into their domain type instead of through generic maps.
+
+func BenchmarkMapMsearchGeneric(b *testing.B) {
+	payload := msearchPayload(20)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(payload)))
+	for i := 0; i < b.N; i++ {
+		if _, _, err := mapGeneric(payload); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMapMsearchTyped(b *testing.B) {
+	payload := msearchPayload(20)
+	kinds := []SearchableKind{NewNewsKind(), NewAuthorKind()}
+	b.ReportAllocs()
+	b.SetBytes(int64(len(payload)))
+	for i := 0; i < b.N; i++ {
+		if _, _, err := mapTyped(payload, kinds); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	elastic "github.com/elastic/go-elasticsearch/v8"
+	"github.com/google/uuid"
+	"github.com/oSoloTurk/multiple-kind-search/internal/domain"
+	"github.com/oSoloTurk/multiple-kind-search/internal/logger"
+	"github.com/oSoloTurk/multiple-kind-search/internal/querylang"
+)
+
+// savedSearchIndex holds saved searches along with their news query in the
+// percolator field savedSearchQueryField. It maps the news fields those
+// queries use alongside the saved search's own.
+const (
+	savedSearchIndex      = "saved-searches"
+	savedSearchQueryField = "match"
+)
+
+// savedSearchDocument is a saved search as stored, with the news query it
+// stands for.
+type savedSearchDocument struct {
+	domain.SavedSearch
+	Match map[string]interface{} `json:"match"`
+}
+
+type savedSearchRepository struct {
+	client *elastic.Client
+}
+
+func NewSavedSearchRepository(client *elastic.Client) domain.SavedSearchRepository {
+	return &savedSearchRepository{client: client}
+}
+
+func (r *savedSearchRepository) Create(search *domain.SavedSearch) error {
+	if search.ID == "" {
+		search.ID = uuid.New().String()
+	}
+	now := time.Now()
+	search.CreatedAt = now
+	search.UpdatedAt = now
+
+	logger.Logger.Info().
+		Str("id", search.ID).
+		Str("name", search.Name).
+		Msg("Creating saved search")
+
+	if err := r.index(search); err != nil {
+		logger.Logger.Error().
+			Err(err).
+			Str("id", search.ID).
+			Msg("Failed to create saved search")
+		return err
+	}
+	return nil
+}
+
+func (r *savedSearchRepository) GetByID(id string) (*domain.SavedSearch, error) {
+	res, err := r.client.Get(
+		savedSearchIndex,
+		id,
+		r.client.Get.WithContext(context.Background()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var result getResponse
+	if err := decodeResponse(res, &result); err != nil {
+		return nil, fmt.Errorf("failed to get saved search %s: %w", id, err)
+	}
+
+	var search domain.SavedSearch
+	if err := decodeSource(result.Source, &search); err != nil {
+		return nil, err
+	}
+	return &search, nil
+}
+
+// Update replaces a saved search, keeping its creation time, and rebuilds
+// its news query.
+func (r *savedSearchRepository) Update(search *domain.SavedSearch) error {
+	existing, err := r.GetByID(search.ID)
+	if err != nil {
+		return err
+	}
+	search.CreatedAt = existing.CreatedAt
+	search.UpdatedAt = time.Now()
+
+	if err := r.index(search); err != nil {
+		return fmt.Errorf("failed to update saved search %s: %w", search.ID, err)
+	}
+	return nil
+}
+
+func (r *savedSearchRepository) Delete(id string) error {
+	res, err := r.client.Delete(
+		savedSearchIndex,
+		id,
+		r.client.Delete.WithContext(context.Background()),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if err := decodeResponse(res, nil); err != nil {
+		return fmt.Errorf("failed to delete saved search %s: %w", id, err)
+	}
+	return nil
+}
+
+func (r *savedSearchRepository) List(filter domain.ListFilter) (*domain.SavedSearchPage, error) {
+	query, err := buildListQuery(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := r.client.Search(
+		r.client.Search.WithIndex(savedSearchIndex),
+		r.client.Search.WithBody(strings.NewReader(string(body))),
+		r.client.Search.WithContext(context.Background()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var result searchResponse
+	if err := decodeResponse(res, &result); err != nil {
+		return nil, fmt.Errorf("failed to list saved searches: %w", err)
+	}
+
+	hits := result.Hits.Hits
+	page := &domain.SavedSearchPage{Items: make([]domain.SavedSearch, 0, len(hits))}
+
+	for i, hit := range hits {
+		if i == filter.Size {
+			// The extra hit only signals that another page follows
+			page.Next = encodeCursor(hits[i-1].Sort)
+			break
+		}
+
+		var search domain.SavedSearch
+		if err := decodeSource(hit.Source, &search); err != nil {
+			return nil, err
+		}
+		page.Items = append(page.Items, search)
+	}
+
+	return page, nil
+}
+
+// index stores a saved search under its ID with the news query it stands for.
+func (r *savedSearchRepository) index(search *domain.SavedSearch) error {
+	query, err := savedSearchQuery(search)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(savedSearchDocument{SavedSearch: *search, Match: query})
+	if err != nil {
+		return err
+	}
+
+	res, err := r.client.Index(
+		savedSearchIndex,
+		strings.NewReader(string(body)),
+		r.client.Index.WithDocumentID(search.ID),
+		r.client.Index.WithContext(context.Background()),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	return decodeResponse(res, nil)
+}
+
+// savedSearchQuery builds the query matching the news a saved search would
+// find, the same query news are searched with. A saved search without a
+// query matches every article its filters accept.
+func savedSearchQuery(search *domain.SavedSearch) (map[string]interface{}, error) {
+	filter := search.SearchFilter()
+	filter.Parsed = &domain.ParsedQuery{Clauses: make([]domain.QueryClause, 0)}
+	if filter.Query != "" {
+		parsed, err := querylang.Parse(filter.Query)
+		if err != nil {
+			return nil, err
+		}
+		filter.Parsed = parsed
+	}
+
+	return newsQuery(filter), nil
+}
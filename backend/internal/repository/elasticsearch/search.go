@@ -2,273 +2,442 @@ package elasticsearch
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
+	"fmt"
 	"sort"
 	"strings"
-	"sync"
 
 	es "github.com/elastic/go-elasticsearch/v8"
 	"github.com/oSoloTurk/multiple-kind-search/internal/domain"
 	"github.com/oSoloTurk/multiple-kind-search/internal/logger"
+	"github.com/oSoloTurk/multiple-kind-search/internal/querylang"
 )
 
+// correctionSuggestion names the phrase suggester in search requests.
+const correctionSuggestion = "correction"
+
 type SearchRepository struct {
 	client *es.Client
+	kinds  *KindRegistry
 }
 
-func NewSearchRepository(client *es.Client) domain.SearchRepository {
-	return &SearchRepository{client: client}
+// KindHits is the outcome of searching a single result type: the top hits,
+// the total number of matches, and whether Elasticsearch timed out before
+// every shard answered, in which case both are incomplete.
+type KindHits struct {
+	Results    []domain.SearchResult
+	Total      int64
+	TimedOut   bool
+	Facets     map[string][]domain.FacetBucket
+	Correction string
 }
 
-func (r *SearchRepository) Search(ctx context.Context, filter domain.SearchFilter) ([]domain.SearchResult, error) {
-	results := make([]domain.SearchResult, 0)
-	log := logger.Logger.With().Str("query", filter.Query).Str("username", filter.Username).Logger()
-	log.Info().Msg("Starting combined search operation")
+// lookup is the search of a resolving kind for the names a search refers
+// to, answered by the msearch response at index response.
+type lookup struct {
+	kind     ResolvingKind
+	names    []string
+	response int
+}
 
-	// Create a wait group to wait for both goroutines to finish
-	var wg sync.WaitGroup
-	wg.Add(2)
+// NewSearchRepository returns a repository whose combined search fans out
+// over every kind in the registry.
+func NewSearchRepository(client *es.Client, kinds *KindRegistry) domain.SearchRepository {
+	return &SearchRepository{client: client, kinds: kinds}
+}
 
-	// Use slices to collect results
-	var authorResults []domain.SearchResult
-	var newsResults []domain.SearchResult
+func (r *SearchRepository) Search(ctx context.Context, filter domain.SearchFilter) (*domain.SearchResponse, error) {
+	response, err := r.search(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
 
-	// Search for authors asynchronously
-	go func() {
-		defer wg.Done()
-		log.Info().Msg("Searching for authors")
-		authors, err := r.SearchAuthor(ctx, filter)
-		if err != nil {
-			log.Error().Err(err).Msg("Error searching for authors")
-			return
-		}
-		authorResults = authors
-		log.Info().Int("authorCount", len(authors)).Msg("Authors search completed")
-	}()
-
-	// Search for news asynchronously
-	go func() {
-		defer wg.Done()
-		log.Info().Msg("Searching for news")
-		news, err := r.SearchNews(ctx, filter)
+	if filter.AutoCorrect && response.Total == 0 && !response.Partial && response.DidYouMean != "" {
+		logger.Logger.Info().
+			Str("query", filter.Query).
+			Str("correctedQuery", response.DidYouMean).
+			Msg("No hits, searching for the corrected query")
+
+		corrected := filter
+		corrected.Query = response.DidYouMean
+		correctedResponse, err := r.search(ctx, corrected)
 		if err != nil {
-			log.Error().Err(err).Msg("Error searching for news")
-			return
+			return nil, err
 		}
-		newsResults = news
-		log.Info().Int("newsCount", len(news)).Msg("News search completed")
-	}()
-
-	wg.Wait()
-
-	// Combine results
-	results = append(results, authorResults...)
-	results = append(results, newsResults...)
-
-	// Sort results by score in descending order
-	sort.Slice(results, func(i, j int) bool {
-		return results[i].Score > results[j].Score
-	})
+		correctedResponse.DidYouMean = response.DidYouMean
+		correctedResponse.CorrectedQuery = response.DidYouMean
+		return correctedResponse, nil
+	}
 
-	log.Info().Int("totalResults", len(results)).Msg("Combined search operation completed successfully")
-	return results, nil
+	return response, nil
 }
 
-func (r *SearchRepository) SearchAuthor(ctx context.Context, filter domain.SearchFilter) ([]domain.SearchResult, error) {
-	// Build the search query for authors
-	query := map[string]interface{}{
-		"query": map[string]interface{}{
-			"multi_match": map[string]interface{}{
-				"query":       filter.Query,
-				"fields":      []string{"name", "bio"},
-				"type":        "best_fields",
-				"tie_breaker": 0.3,
-			},
-		},
-		"highlight": map[string]interface{}{
-			"fields": map[string]interface{}{
-				"name": map[string]interface{}{},
-				"bio":  map[string]interface{}{},
-			},
-			"pre_tags":  []string{"<em>"},
-			"post_tags": []string{"</em>"},
-		},
-	}
+func (r *SearchRepository) search(ctx context.Context, filter domain.SearchFilter) (*domain.SearchResponse, error) {
+	log := logger.Logger.With().Str("query", filter.Query).Int("boosts", len(filter.Boosts)).Logger()
+	log.Info().Int("from", filter.From).Int("size", filter.Size).Msg("Starting combined search operation")
 
-	body, err := json.Marshal(query)
+	merger, err := lookupMerger(filter.Merge)
 	if err != nil {
 		return nil, err
 	}
-
-	res, err := r.client.Search(
-		r.client.Search.WithIndex("authors"),
-		r.client.Search.WithBody(strings.NewReader(string(body))),
-		r.client.Search.WithContext(ctx),
-	)
+	weights, err := r.mergeWeights(filter.Weights)
 	if err != nil {
 		return nil, err
 	}
-	defer res.Body.Close()
 
-	var result map[string]interface{}
-	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+	selected, err := r.kinds.Select(filter.Types)
+	if err != nil {
+		return nil, err
+	}
+	filter.Parsed, err = querylang.Parse(filter.Query)
+	if err != nil {
 		return nil, err
 	}
 
-	hits := result["hits"].(map[string]interface{})["hits"].([]interface{})
-	authors := make([]domain.SearchResult, 0)
-
-	for _, hit := range hits {
-		hitMap := hit.(map[string]interface{})
-		source := hitMap["_source"].(map[string]interface{})
-		highlights := hitMap["highlight"].(map[string]interface{})
-		score := hitMap["_score"].(float64)
+	kinds := make([]SearchableKind, 0, len(selected))
+	for _, kind := range selected {
+		if filtered, ok := kind.(FilteredKind); ok && !filtered.Accepts(filter) {
+			continue
+		}
+		kinds = append(kinds, kind)
+	}
 
-		var author domain.Author
-		sourceBytes, err := json.Marshal(source)
+	// Every kind is searched, and the documents the search refers to by name
+	// are looked up, in a single _msearch. Each kind still succeeds or fails
+	// on its own.
+	type outcome struct {
+		hits *KindHits
+		err  error
+	}
+	outcomes := make(map[domain.SearchResultType]*outcome, len(kinds))
+	requests := make([]msearchRequest, 0, len(kinds)+1)
+	searched := make([]SearchableKind, 0, len(kinds))
+	for _, kind := range kinds {
+		out := &outcome{}
+		outcomes[kind.Type()] = out
+
+		body, err := kindRequest(ctx, kind, filter)
 		if err != nil {
-			return nil, err
+			out.err = err
+			continue
 		}
-		if err := json.Unmarshal(sourceBytes, &author); err != nil {
-			return nil, err
+		requests = append(requests, msearchRequest{Index: kind.Index(), Body: body})
+		searched = append(searched, kind)
+	}
+	lookups := make([]lookup, 0)
+	for _, kind := range r.kinds.Kinds() {
+		resolving, ok := kind.(ResolvingKind)
+		if !ok {
+			continue
 		}
-
-		authors = append(authors, domain.SearchResult{
-			ID:      author.ID,
-			Title:   GetValueWithHighlight(highlights, "name", author.Name),
-			Content: GetValueWithHighlight(highlights, "bio", author.Bio),
-			Score:   score,
-			Type:    domain.AuthorResultType,
-		})
+		names, query := resolving.LookupQuery(filter)
+		if len(names) == 0 {
+			continue
+		}
+		lookups = append(lookups, lookup{kind: resolving, names: names, response: len(requests)})
+		requests = append(requests, msearchRequest{Index: kind.Index(), Body: query})
 	}
 
-	return authors, nil
-}
+	var responses []msearchItem
+	var searchErr error
+	if len(requests) > 0 {
+		// The timeout bounds the single request searching every kind
+		searchCtx := ctx
+		if filter.Timeout > 0 {
+			var cancel context.CancelFunc
+			searchCtx, cancel = context.WithTimeout(ctx, filter.Timeout)
+			defer cancel()
+		}
+		responses, searchErr = msearch(searchCtx, r.client, requests)
+		if searchErr != nil {
+			log.Error().Err(searchErr).Msg("Error running combined search")
+		}
+	}
 
-func (r *SearchRepository) SearchNews(ctx context.Context, filter domain.SearchFilter) ([]domain.SearchResult, error) {
-	// First find author ID if username is provided
-	var authorID string
-	if filter.Username != "" {
-		authorQuery := map[string]interface{}{
-			"query": map[string]interface{}{
-				"match": map[string]interface{}{
-					"name": filter.Username,
-				},
-			},
-			"size": 1,
+	for i, kind := range searched {
+		out := outcomes[kind.Type()]
+		resultType := string(kind.Type())
+		switch {
+		case searchErr != nil:
+			out.err = searchErr
+		case responses[i].Error != nil:
+			out.err = fmt.Errorf("failed to search %s: %w", kind.Index(), responses[i].Error)
+		default:
+			out.hits, out.err = kindHits(ctx, kind, filter, &responses[i].searchResponse)
 		}
+		if out.err != nil {
+			log.Error().Err(out.err).Str("type", resultType).Msg("Error searching result type")
+			continue
+		}
+		log.Info().
+			Str("type", resultType).
+			Int("count", len(out.hits.Results)).
+			Int64("total", out.hits.Total).
+			Bool("timedOut", out.hits.TimedOut).
+			Msg("Result type search completed")
+	}
 
-		authorBody, err := json.Marshal(authorQuery)
-		if err != nil {
-			return nil, err
+	var unresolved []string
+	for _, l := range lookups {
+		if searchErr != nil {
+			// The lookups failed along with the search
+			break
+		}
+		result := responses[l.response]
+		if result.Error != nil {
+			// Only the report of unknown names is lost, the search went ahead
+			log.Warn().Err(result.Error).Msg("Failed to look up documents by name")
+			continue
 		}
+		unresolved = append(unresolved, l.kind.Unresolved(l.names, &result.searchResponse)...)
+	}
 
-		authorRes, err := r.client.Search(
-			r.client.Search.WithIndex("authors"),
-			r.client.Search.WithBody(strings.NewReader(string(authorBody))),
-			r.client.Search.WithContext(ctx),
-		)
-		if err != nil {
-			return nil, err
+	response := &domain.SearchResponse{
+		Totals:            make(map[domain.SearchResultType]int64, len(outcomes)),
+		Status:            make(map[domain.SearchResultType]domain.KindStatus, len(outcomes)),
+		From:              filter.From,
+		Size:              filter.Size,
+		UnresolvedAuthors: unresolved,
+	}
+	hitsByType := make(map[domain.SearchResultType][]domain.SearchResult, len(outcomes))
+	failures := make([]string, 0)
+
+	for resultType, out := range outcomes {
+		status := kindStatus(out.hits, out.err)
+		response.Status[resultType] = status
+		if status.State != domain.SearchStateOK {
+			response.Partial = true
+			failures = append(failures, fmt.Sprintf("%s %s", resultType, status.State))
+		}
+		if out.hits == nil {
+			response.Totals[resultType] = 0
+			continue
 		}
-		defer authorRes.Body.Close()
+		hitsByType[resultType] = out.hits.Results
+		response.Totals[resultType] = out.hits.Total
+		response.Total += out.hits.Total
+	}
 
-		var authorResult map[string]interface{}
-		if err := json.NewDecoder(authorRes.Body).Decode(&authorResult); err != nil {
-			return nil, err
+	// Suggester scores are not comparable across indices, so the correction
+	// is taken from the kind with the most hits, the first one on a tie
+	var corrector *KindHits
+	for _, kind := range kinds {
+		hits := outcomes[kind.Type()].hits
+		if hits != nil && hits.Correction != "" && (corrector == nil || hits.Total > corrector.Total) {
+			corrector = hits
 		}
+	}
+	if corrector != nil {
+		response.DidYouMean = corrector.Correction
+	}
 
-		hits := authorResult["hits"].(map[string]interface{})["hits"].([]interface{})
-		if len(hits) > 0 {
-			hitMap := hits[0].(map[string]interface{})
-			source := hitMap["_source"].(map[string]interface{})
-			authorID = source["id"].(string)
+	if filter.Facets {
+		kindFacets := make([]map[string][]domain.FacetBucket, 0, len(outcomes))
+		for _, out := range outcomes {
+			if out.hits != nil && out.hits.Facets != nil {
+				kindFacets = append(kindFacets, out.hits.Facets)
+			}
 		}
+		response.Facets = mergeFacets(response.Totals, kindFacets)
 	}
 
-	// Build the search query for news
-	query := map[string]interface{}{
-		"query": map[string]interface{}{
-			"bool": map[string]interface{}{
-				"must": map[string]interface{}{
-					"multi_match": map[string]interface{}{
-						"query":       filter.Query,
-						"fields":      []string{"title", "content"},
-						"type":        "best_fields",
-						"tie_breaker": 0.3,
-					},
-				},
-			},
-		},
-		"highlight": map[string]interface{}{
-			"fields": map[string]interface{}{
-				"title":   map[string]interface{}{},
-				"content": map[string]interface{}{},
-			},
-			"pre_tags":  []string{"<em>"},
-			"post_tags": []string{"</em>"},
-		},
+	if filter.Strict && response.Partial {
+		sort.Strings(failures)
+		return nil, fmt.Errorf("%w: %s", domain.ErrSearchIncomplete, strings.Join(failures, ", "))
 	}
 
-	// Add author boost if we have an author ID
-	if authorID != "" {
-		query["query"].(map[string]interface{})["bool"].(map[string]interface{})["should"] = map[string]interface{}{
-			"term": map[string]interface{}{
-				"authorID": map[string]interface{}{
-					"value": authorID,
-					"boost": 2.0,
-				},
-			},
+	// Combine results
+	results := mergeResults(hitsByType, merger, weights)
+	response.Results = paginate(results, filter.From, filter.Size)
+
+	log.Info().
+		Int64("totalResults", response.Total).
+		Int("pageResults", len(response.Results)).
+		Bool("partial", response.Partial).
+		Msg("Combined search operation completed")
+	return response, nil
+}
+
+// mergeWeights returns the weight of every registered kind, taking the
+// request's weights over each kind's default. A weight for a type no kind is
+// registered for fails with ErrUnknownResultType, as selecting it would.
+func (r *SearchRepository) mergeWeights(overrides map[domain.SearchResultType]float64) (map[domain.SearchResultType]float64, error) {
+	weights := make(map[domain.SearchResultType]float64)
+	for _, kind := range r.kinds.Kinds() {
+		weights[kind.Type()] = kind.Weight()
+	}
+	for resultType, weight := range overrides {
+		if _, ok := r.kinds.Get(resultType); !ok {
+			return nil, fmt.Errorf("%w: %s", domain.ErrUnknownResultType, resultType)
 		}
+		weights[resultType] = weight
 	}
+	return weights, nil
+}
 
-	body, err := json.Marshal(query)
-	if err != nil {
-		return nil, err
+// kindStatus reports how the search over one result type ended.
+func kindStatus(hits *KindHits, err error) domain.KindStatus {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return domain.KindStatus{State: domain.SearchStateTimedOut, Error: err.Error()}
+	case err != nil:
+		return domain.KindStatus{State: domain.SearchStateFailed, Error: err.Error()}
+	case hits.TimedOut:
+		return domain.KindStatus{State: domain.SearchStateTimedOut, Error: "not every shard answered in time"}
+	default:
+		return domain.KindStatus{State: domain.SearchStateOK}
 	}
+}
 
-	res, err := r.client.Search(
-		r.client.Search.WithIndex("news"),
-		r.client.Search.WithBody(strings.NewReader(string(body))),
-		r.client.Search.WithContext(ctx),
-		r.client.Search.WithSize(1000),
-	)
+// kindRequest builds the search of one kind for the top from+size hits,
+// which is the window needed to merge a page of results, counting every
+// match.
+func kindRequest(ctx context.Context, kind SearchableKind, filter domain.SearchFilter) (map[string]interface{}, error) {
+	query, err := kind.Query(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
-	defer res.Body.Close()
-
-	var result map[string]interface{}
-	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
-		return nil, err
+	query["size"] = filter.From + filter.Size
+	query["track_total_hits"] = true
+	if filter.Timeout > 0 {
+		query["timeout"] = fmt.Sprintf("%dms", filter.Timeout.Milliseconds())
 	}
 
-	hits := result["hits"].(map[string]interface{})["hits"].([]interface{})
-	newsResults := make([]domain.SearchResult, 0)
-
-	for _, hit := range hits {
-		hitMap := hit.(map[string]interface{})
-		source := hitMap["_source"].(map[string]interface{})
-		highlights := hitMap["highlight"].(map[string]interface{})
-		score := hitMap["_score"].(float64)
+	// Only free text is spellchecked, as a correction has no place for the
+	// rest of the query syntax
+	if correcting, ok := kind.(CorrectingKind); ok && filter.Parsed.IsPlain() {
+		query["suggest"] = phraseSuggestion(filter.Parsed.FreeText(), correcting.SpellcheckFields())
+	}
+	if faceted, ok := kind.(FacetedKind); ok && filter.Facets {
+		query["aggs"] = faceted.Aggregations(filter)
+	}
+	if filter.Explain {
+		query["explain"] = true
+	}
+	return query, nil
+}
 
-		var news domain.News
-		sourceBytes, err := json.Marshal(source)
+// kindHits reads the response to kindRequest.
+func kindHits(ctx context.Context, kind SearchableKind, filter domain.SearchFilter, result *searchResponse) (*KindHits, error) {
+	results := make([]domain.SearchResult, 0, len(result.Hits.Hits))
+	for _, hit := range result.Hits.Hits {
+		searchResult, err := kind.MapHit(hit)
 		if err != nil {
 			return nil, err
 		}
-		if err := json.Unmarshal(sourceBytes, &news); err != nil {
-			return nil, err
+		if filter.Explain {
+			searchResult.Explanation = explainScore(hit, filter.Merge, filter.Boosts)
 		}
+		results = append(results, searchResult)
+	}
+
+	hits := &KindHits{
+		Results:    results,
+		Total:      result.Hits.Total.Value,
+		TimedOut:   result.TimedOut,
+		Correction: GetCorrection(result),
+	}
+
+	if faceted, ok := kind.(FacetedKind); ok && filter.Facets {
+		var err error
+		hits.Facets, err = faceted.Facets(ctx, result.Aggregations)
+		if err != nil {
+			// Facets are auxiliary, so keep the hits and whatever facets resolved
+			logger.Logger.Warn().Err(err).Str("type", string(kind.Type())).Msg("Failed to resolve facets")
+		}
+	}
 
-		newsResults = append(newsResults, domain.SearchResult{
-			ID:      news.ID,
-			Title:   GetValueWithHighlight(highlights, "title", news.Title),
-			Content: GetValueWithHighlight(highlights, "content", news.Content),
-			Score:   score,
-			Type:    domain.NewsResultType,
+	return hits, nil
+}
+
+// correctionCandidates is the number of corrections the phrase suggester
+// checks against the index, best first.
+const correctionCandidates = 5
+
+// phraseSuggestion builds a phrase suggester proposing the most likely
+// correction of the query, generating candidates from every field. Only
+// corrections that find documents of the index with every term are kept,
+// so that a kind does not propose what it cannot answer.
+func phraseSuggestion(query string, fields []string) map[string]interface{} {
+	generators := make([]interface{}, 0, len(fields))
+	for _, field := range fields {
+		generators = append(generators, map[string]interface{}{
+			"field":        field,
+			"suggest_mode": "missing",
 		})
 	}
 
-	return newsResults, nil
+	return map[string]interface{}{
+		"text": query,
+		correctionSuggestion: map[string]interface{}{
+			"phrase": map[string]interface{}{
+				"field":            fields[0],
+				"size":             correctionCandidates,
+				"max_errors":       2,
+				"direct_generator": generators,
+				"collate": map[string]interface{}{
+					"query": map[string]interface{}{
+						"source": map[string]interface{}{
+							"multi_match": map[string]interface{}{
+								"query":    "{{suggestion}}",
+								"type":     "cross_fields",
+								"fields":   fields,
+								"operator": "and",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// mergeFacets combines the facets of every kind, summing buckets that share
+// a key, and adds the per-type facet from the hit totals.
+func mergeFacets(totals map[domain.SearchResultType]int64, kindFacets []map[string][]domain.FacetBucket) map[string][]domain.FacetBucket {
+	types := make([]domain.FacetBucket, 0, len(totals))
+	for resultType, total := range totals {
+		types = append(types, domain.FacetBucket{Key: string(resultType), Count: total})
+	}
+	sort.Slice(types, func(i, j int) bool {
+		if types[i].Count != types[j].Count {
+			return types[i].Count > types[j].Count
+		}
+		return types[i].Key < types[j].Key
+	})
+
+	facets := map[string][]domain.FacetBucket{domain.TypeFacet: types}
+	for _, kindFacet := range kindFacets {
+		for name, buckets := range kindFacet {
+			if _, ok := facets[name]; !ok {
+				facets[name] = make([]domain.FacetBucket, 0, len(buckets))
+			}
+			for _, bucket := range buckets {
+				merged := false
+				for i := range facets[name] {
+					if facets[name][i].Key == bucket.Key {
+						facets[name][i].Count += bucket.Count
+						merged = true
+						break
+					}
+				}
+				if !merged {
+					facets[name] = append(facets[name], bucket)
+				}
+			}
+		}
+	}
+	return facets
+}
+
+// paginate returns the [from, from+size) window of the merged results.
+func paginate(results []domain.SearchResult, from, size int) []domain.SearchResult {
+	if from >= len(results) {
+		return make([]domain.SearchResult, 0)
+	}
+	end := from + size
+	if end > len(results) {
+		end = len(results)
+	}
+	return results[from:end]
 }
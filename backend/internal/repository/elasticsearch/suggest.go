@@ -0,0 +1,115 @@
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/oSoloTurk/multiple-kind-search/internal/domain"
+	"github.com/oSoloTurk/multiple-kind-search/internal/logger"
+)
+
+// Suggest returns type-ahead suggestions from every suggesting kind, fetched
+// with a single _msearch and interleaved so that each type is represented.
+func (r *SearchRepository) Suggest(ctx context.Context, filter domain.SuggestFilter) ([]domain.Suggestion, error) {
+	selected, err := r.kinds.Select(filter.Types)
+	if err != nil {
+		return nil, err
+	}
+
+	kinds := make([]SuggestingKind, 0, len(selected))
+	requests := make([]msearchRequest, 0, len(selected))
+	for _, kind := range selected {
+		suggesting, ok := kind.(SuggestingKind)
+		if !ok {
+			continue
+		}
+		kinds = append(kinds, suggesting)
+		requests = append(requests, msearchRequest{
+			Index: kind.Index(),
+			Body:  suggesting.SuggestQuery(filter.Prefix, filter.Size),
+		})
+	}
+	if len(kinds) == 0 {
+		return make([]domain.Suggestion, 0), nil
+	}
+
+	responses, err := msearch(ctx, r.client, requests)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch suggestions: %w", err)
+	}
+
+	perKind := make([][]domain.Suggestion, 0, len(kinds))
+	for i, kind := range kinds {
+		response := &responses[i]
+		if response.Error != nil {
+			// Suggestions are best effort, a failing kind is left out
+			logger.Logger.Warn().Err(response.Error).Msg("Failed to fetch suggestions for kind")
+			continue
+		}
+		perKind = append(perKind, kind.MapSuggestions(&response.searchResponse))
+	}
+
+	return interleaveSuggestions(perKind, filter.Size), nil
+}
+
+// interleaveSuggestions takes the suggestions of every type in turns, each
+// list keeping its own order, until size suggestions are collected. Types
+// from one kind, such as news titles and tags, are split into separate turns.
+func interleaveSuggestions(perKind [][]domain.Suggestion, size int) []domain.Suggestion {
+	lists := make([][]domain.Suggestion, 0)
+	for _, suggestions := range perKind {
+		byType := make(map[domain.SearchResultType]int)
+		for _, suggestion := range suggestions {
+			i, ok := byType[suggestion.Type]
+			if !ok {
+				i = len(lists)
+				byType[suggestion.Type] = i
+				lists = append(lists, make([]domain.Suggestion, 0))
+			}
+			lists[i] = append(lists[i], suggestion)
+		}
+	}
+
+	interleaved := make([]domain.Suggestion, 0, size)
+	seen := make(map[string]bool)
+	for round := 0; len(interleaved) < size; round++ {
+		added := false
+		for _, list := range lists {
+			if round >= len(list) || len(interleaved) == size {
+				continue
+			}
+			added = true
+			suggestion := list[round]
+			key := string(suggestion.Type) + "\x00" + strings.ToLower(suggestion.Text)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			interleaved = append(interleaved, suggestion)
+		}
+		if !added {
+			break
+		}
+	}
+	return interleaved
+}
+
+// searchAsYouTypeFields lists a search_as_you_type field and its shingle
+// subfields for a bool_prefix multi_match.
+func searchAsYouTypeFields(field string) []string {
+	return []string{field, field + "._2gram", field + "._3gram"}
+}
+
+// escapeRegexp escapes the characters that are special in Lucene regular
+// expressions.
+func escapeRegexp(value string) string {
+	var escaped strings.Builder
+	for _, r := range value {
+		if strings.ContainsRune(`.?+*|{}[]()"\#@&<>~`, r) {
+			escaped.WriteRune('\\')
+		}
+		escaped.WriteRune(r)
+	}
+	return escaped.String()
+}
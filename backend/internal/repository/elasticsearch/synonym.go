@@ -0,0 +1,241 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	elastic "github.com/elastic/go-elasticsearch/v8"
+	"github.com/oSoloTurk/multiple-kind-search/internal/domain"
+	"github.com/oSoloTurk/multiple-kind-search/internal/logger"
+)
+
+const (
+	// synonymVersionIndex keeps every saved version of the synonym sets.
+	synonymVersionIndex = "synonym-versions"
+	// searchSynonymsSet is the Elasticsearch synonyms set read by the
+	// search analyzers of the news and authors indices.
+	searchSynonymsSet = "search-synonyms"
+)
+
+// synonymVersionSort orders versions newest first.
+var synonymVersionSort = []map[string]interface{}{
+	{"version": map[string]interface{}{"order": "desc"}},
+}
+
+type synonymRepository struct {
+	client *elastic.Client
+}
+
+// NewSynonymRepository returns a repository that versions synonym sets in
+// their own index and applies the newest to search.
+func NewSynonymRepository(client *elastic.Client) domain.SynonymRepository {
+	return &synonymRepository{client: client}
+}
+
+func (r *synonymRepository) Latest() (*domain.SynonymVersion, error) {
+	page, err := r.ListVersions(domain.ListFilter{Size: 1})
+	if errors.Is(err, domain.ErrNotFound) {
+		// The index is created with the first version
+		return &domain.SynonymVersion{Sets: make([]domain.SynonymSet, 0)}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(page.Items) == 0 {
+		return &domain.SynonymVersion{Sets: make([]domain.SynonymSet, 0)}, nil
+	}
+	return &page.Items[0], nil
+}
+
+func (r *synonymRepository) GetVersion(version int) (*domain.SynonymVersion, error) {
+	res, err := r.client.Get(
+		synonymVersionIndex,
+		strconv.Itoa(version),
+		r.client.Get.WithContext(context.Background()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var result getResponse
+	if err := decodeResponse(res, &result); err != nil {
+		return nil, fmt.Errorf("failed to get synonym version %d: %w", version, err)
+	}
+
+	var synonymVersion domain.SynonymVersion
+	if err := decodeSource(result.Source, &synonymVersion); err != nil {
+		return nil, err
+	}
+	return &synonymVersion, nil
+}
+
+func (r *synonymRepository) ListVersions(filter domain.ListFilter) (*domain.SynonymVersionPage, error) {
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"match_all": map[string]interface{}{},
+		},
+		"size": filter.Size + 1,
+		"sort": synonymVersionSort,
+	}
+	if filter.Cursor != "" {
+		searchAfter, err := decodeCursor(filter.Cursor, len(synonymVersionSort))
+		if err != nil {
+			return nil, err
+		}
+		query["search_after"] = searchAfter
+	}
+
+	body, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := r.client.Search(
+		r.client.Search.WithIndex(synonymVersionIndex),
+		r.client.Search.WithBody(strings.NewReader(string(body))),
+		r.client.Search.WithContext(context.Background()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var result searchResponse
+	if err := decodeResponse(res, &result); err != nil {
+		return nil, fmt.Errorf("failed to list synonym versions: %w", err)
+	}
+
+	hits := result.Hits.Hits
+	page := &domain.SynonymVersionPage{Items: make([]domain.SynonymVersion, 0, len(hits))}
+
+	for i, hit := range hits {
+		if i == filter.Size {
+			// The extra hit only signals that another page follows
+			page.Next = encodeCursor(hits[i-1].Sort)
+			break
+		}
+
+		var version domain.SynonymVersion
+		if err := decodeSource(hit.Source, &version); err != nil {
+			return nil, err
+		}
+		page.Items = append(page.Items, version)
+	}
+
+	return page, nil
+}
+
+// Save stores the version under its number, which fails when the number is
+// taken, and then applies it. Should applying fail, the version is stored
+// but not in effect until the next change or rollback applies the sets.
+func (r *synonymRepository) Save(version *domain.SynonymVersion) error {
+	logger.Logger.Info().
+		Int("version", version.Version).
+		Str("change", version.Change).
+		Int("sets", len(version.Sets)).
+		Msg("Saving synonym version")
+
+	body, err := json.Marshal(version)
+	if err != nil {
+		return err
+	}
+
+	res, err := r.client.Index(
+		synonymVersionIndex,
+		strings.NewReader(string(body)),
+		r.client.Index.WithDocumentID(strconv.Itoa(version.Version)),
+		r.client.Index.WithOpType("create"),
+		r.client.Index.WithRefresh("wait_for"),
+		r.client.Index.WithContext(context.Background()),
+	)
+	if err == nil {
+		defer res.Body.Close()
+		err = decodeResponse(res, nil)
+	}
+	if err != nil {
+		logger.Logger.Error().
+			Err(err).
+			Int("version", version.Version).
+			Msg("Failed to save synonym version")
+		return fmt.Errorf("failed to save synonym version %d: %w", version.Version, err)
+	}
+
+	if err := r.apply(version.Sets); err != nil {
+		logger.Logger.Error().
+			Err(err).
+			Int("version", version.Version).
+			Msg("Failed to apply synonym version")
+		return fmt.Errorf("failed to apply synonym version %d: %w", version.Version, err)
+	}
+	return nil
+}
+
+// apply replaces the rules of the search synonyms set with the sets and
+// reloads the search analyzers, so that searches use them right away.
+func (r *synonymRepository) apply(sets []domain.SynonymSet) error {
+	rules := make([]interface{}, 0, len(sets))
+	for _, set := range sets {
+		rules = append(rules, map[string]interface{}{
+			"id":       set.ID,
+			"synonyms": strings.Join(set.Terms, ", "),
+		})
+	}
+	body, err := json.Marshal(map[string]interface{}{"synonyms_set": rules})
+	if err != nil {
+		return err
+	}
+
+	res, err := r.client.SynonymsPutSynonym(
+		searchSynonymsSet,
+		strings.NewReader(string(body)),
+		r.client.SynonymsPutSynonym.WithContext(context.Background()),
+	)
+	if err == nil {
+		defer res.Body.Close()
+		err = decodeResponse(res, nil)
+	}
+	if err != nil {
+		return err
+	}
+
+	reload, err := r.client.Indices.ReloadSearchAnalyzers(
+		[]string{newsIndex, authorIndex, savedSearchIndex},
+		r.client.Indices.ReloadSearchAnalyzers.WithContext(context.Background()),
+	)
+	if err != nil {
+		return err
+	}
+	defer reload.Body.Close()
+	if err := decodeResponse(reload, nil); err != nil {
+		return err
+	}
+	return r.reindexSavedSearches()
+}
+
+// reindexSavedSearches rewrites every saved search in place. A percolator
+// field picks the terms that preselect its query when the query is indexed,
+// so the saved searches only follow the new synonyms once indexed again.
+func (r *synonymRepository) reindexSavedSearches() error {
+	res, err := r.client.UpdateByQuery(
+		[]string{savedSearchIndex},
+		r.client.UpdateByQuery.WithConflicts("proceed"),
+		r.client.UpdateByQuery.WithRefresh(true),
+		r.client.UpdateByQuery.WithContext(context.Background()),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if err := decodeResponse(res, nil); errors.Is(err, domain.ErrNotFound) {
+		// No saved search was created yet
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to reindex saved searches: %w", err)
+	}
+	return nil
+}
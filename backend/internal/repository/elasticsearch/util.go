@@ -1,9 +1,137 @@
 package elasticsearch
 
-func GetValueWithHighlight(hit map[string]interface{}, key string, defaultValue string) string {
-	if value, ok := hit[key]; ok {
-		values := value.([]interface{})
-		return values[0].(string)
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/oSoloTurk/multiple-kind-search/internal/domain"
+)
+
+// decodeSource unmarshals the _source of a hit or document into v.
+func decodeSource(source json.RawMessage, v interface{}) error {
+	if len(source) == 0 {
+		return fmt.Errorf("document has no source")
+	}
+	return json.Unmarshal(source, v)
+}
+
+// facetSize is the number of buckets returned for terms facets.
+const facetSize = 20
+
+// facetValues names the aggregation counting the values of a facet inside
+// the filter aggregation that narrows its hits.
+const facetValues = "values"
+
+// unwrapFacets returns the aggregations counting facet values in place of
+// the filter aggregations wrapping them.
+func unwrapFacets(aggregations map[string]json.RawMessage) (map[string]json.RawMessage, error) {
+	unwrapped := make(map[string]json.RawMessage, len(aggregations))
+	for name, raw := range aggregations {
+		var filtered map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &filtered); err != nil {
+			return nil, fmt.Errorf("failed to decode %s aggregation: %w", name, err)
+		}
+		if values, ok := filtered[facetValues]; ok {
+			unwrapped[name] = values
+		}
+	}
+	return unwrapped, nil
+}
+
+// GetBuckets reads the buckets of a terms or histogram aggregation. A missing
+// or malformed aggregation has no buckets.
+func GetBuckets(aggregations map[string]json.RawMessage, name string) []domain.FacetBucket {
+	var aggregation bucketAggregation
+	if raw, ok := aggregations[name]; ok {
+		_ = json.Unmarshal(raw, &aggregation)
+	}
+	return aggregation.facetBuckets()
+}
+
+func (a bucketAggregation) facetBuckets() []domain.FacetBucket {
+	buckets := make([]domain.FacetBucket, 0, len(a.Buckets))
+	for _, bucket := range a.Buckets {
+		buckets = append(buckets, domain.FacetBucket{Key: bucket.bucketKey(), Count: bucket.DocCount})
+	}
+	return buckets
+}
+
+// bucketKey returns key_as_string when Elasticsearch formats the key, and
+// the key itself otherwise.
+func (b aggregationBucket) bucketKey() string {
+	if b.KeyAsString != "" {
+		return b.KeyAsString
+	}
+	// Keys are strings for keyword fields and numbers otherwise
+	var key string
+	if err := json.Unmarshal(b.Key, &key); err != nil {
+		key = string(b.Key)
+	}
+	return key
+}
+
+// GetCorrection reads the best option of the phrase suggester, or an empty
+// string when it proposes none.
+func GetCorrection(result *searchResponse) string {
+	entries := result.Suggest[correctionSuggestion]
+	if len(entries) == 0 || len(entries[0].Options) == 0 {
+		return ""
+	}
+	return entries[0].Options[0].Text
+}
+
+// listSort orders listings newest first with the document id as a tie-breaker,
+// giving search_after a stable position to resume from.
+var listSort = []map[string]interface{}{
+	{"createdAt": map[string]interface{}{"order": "desc"}},
+	{"id": map[string]interface{}{"order": "asc"}},
+}
+
+// buildListQuery builds a match_all listing query for one page. One extra hit
+// is requested so the caller can tell whether another page follows.
+func buildListQuery(filter domain.ListFilter) (map[string]interface{}, error) {
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"match_all": map[string]interface{}{},
+		},
+		"size": filter.Size + 1,
+		"sort": listSort,
+	}
+
+	if filter.Cursor != "" {
+		searchAfter, err := decodeCursor(filter.Cursor, len(listSort))
+		if err != nil {
+			return nil, err
+		}
+		query["search_after"] = searchAfter
+	}
+
+	return query, nil
+}
+
+// encodeCursor turns the sort values of the last hit on a page into an opaque
+// token for the next page.
+func encodeCursor(sortValues json.RawMessage) string {
+	return base64.RawURLEncoding.EncodeToString(sortValues)
+}
+
+// decodeCursor reads the sort values of a cursor, which must hold one value
+// for each of the fields sorted on.
+func decodeCursor(cursor string, fields int) ([]interface{}, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, domain.ErrInvalidCursor
+	}
+
+	// Keep numbers as json.Number so epoch millis survive the round trip
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var sortValues []interface{}
+	if err := decoder.Decode(&sortValues); err != nil || len(sortValues) != fields {
+		return nil, domain.ErrInvalidCursor
 	}
-	return defaultValue
+	return sortValues, nil
 }
@@ -0,0 +1,58 @@
+package elasticsearch
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/oSoloTurk/multiple-kind-search/internal/domain"
+)
+
+func TestDecodeCursor(t *testing.T) {
+	sortValues := json.RawMessage(`[1709251200000,"news-1"]`)
+	tests := []struct {
+		name   string
+		cursor string
+		want   []interface{}
+	}{
+		{
+			name:   "round trip",
+			cursor: encodeCursor(sortValues),
+			want:   []interface{}{json.Number("1709251200000"), "news-1"},
+		},
+		{name: "empty", cursor: ""},
+		{name: "malformed base64", cursor: "not a cursor!"},
+		{name: "padded base64", cursor: base64.URLEncoding.EncodeToString([]byte(`[1709251200000,"news-10"]`))},
+		{name: "not json", cursor: base64.RawURLEncoding.EncodeToString([]byte("1709251200000,news-1"))},
+		{name: "not an array", cursor: base64.RawURLEncoding.EncodeToString([]byte(`{"createdAt":1709251200000}`))},
+		{name: "too few sort values", cursor: encodeCursor(json.RawMessage(`[1709251200000]`))},
+		{name: "too many sort values", cursor: encodeCursor(json.RawMessage(`[1709251200000,"news-1",3]`))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeCursor(tt.cursor, len(listSort))
+			if tt.want == nil {
+				// Handlers compare against the sentinel to answer 400
+				if err != domain.ErrInvalidCursor {
+					t.Fatalf("decodeCursor(%q) error = %v, want %v", tt.cursor, err, domain.ErrInvalidCursor)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodeCursor(%q) error = %v", tt.cursor, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("decodeCursor(%q) = %#v, want %#v", tt.cursor, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildListQueryInvalidCursor(t *testing.T) {
+	filter := domain.ListFilter{Size: 10, Cursor: encodeCursor(json.RawMessage(`["news-1"]`))}
+	if _, err := buildListQuery(filter); err != domain.ErrInvalidCursor {
+		t.Errorf("buildListQuery error = %v, want %v", err, domain.ErrInvalidCursor)
+	}
+}
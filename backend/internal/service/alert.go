@@ -0,0 +1,20 @@
+package service
+
+import (
+	"github.com/oSoloTurk/multiple-kind-search/internal/domain"
+)
+
+type alertService struct {
+	repo domain.AlertRepository
+}
+
+func NewAlertService(repo domain.AlertRepository) domain.AlertService {
+	return &alertService{repo: repo}
+}
+
+func (s *alertService) List(filter domain.AlertFilter) (*domain.AlertPage, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.List(filter)
+}
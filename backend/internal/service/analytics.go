@@ -0,0 +1,52 @@
+package service
+
+import (
+	"time"
+
+	"github.com/oSoloTurk/multiple-kind-search/internal/domain"
+)
+
+type analyticsService struct {
+	repo domain.AnalyticsRepository
+}
+
+func NewAnalyticsService(repo domain.AnalyticsRepository) domain.AnalyticsService {
+	return &analyticsService{repo: repo}
+}
+
+// RecordClick records a click on a result of a recorded search, failing
+// with ErrNotFound for unknown searches.
+func (s *analyticsService) RecordClick(click *domain.ClickEvent) error {
+	if err := click.Validate(); err != nil {
+		return err
+	}
+	search, err := s.repo.GetSearch(click.SearchID)
+	if err != nil {
+		return err
+	}
+	click.Query = search.Query
+	click.NormalizedQuery = search.NormalizedQuery
+	click.Timestamp = time.Now()
+	return s.repo.RecordClick(click)
+}
+
+func (s *analyticsService) TopQueries(filter domain.ReportFilter) ([]domain.QueryCount, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.TopQueries(filter)
+}
+
+func (s *analyticsService) ZeroResultQueries(filter domain.ReportFilter) ([]domain.QueryCount, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.ZeroResultQueries(filter)
+}
+
+func (s *analyticsService) ClickThrough(filter domain.ReportFilter) (*domain.ClickThroughReport, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.ClickThrough(filter)
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"github.com/oSoloTurk/multiple-kind-search/internal/domain"
+	"github.com/oSoloTurk/multiple-kind-search/internal/langdetect"
 )
 
 type authorService struct {
@@ -16,6 +17,9 @@ func (s *authorService) Create(author *domain.Author) error {
 	if err := author.Validate(); err != nil {
 		return err
 	}
+	if author.Language == "" {
+		author.Language = langdetect.Detect(author.Name + "\n" + author.Bio)
+	}
 	return s.repo.Create(author)
 }
 
@@ -27,6 +31,9 @@ func (s *authorService) Update(author *domain.Author) error {
 	if err := author.Validate(); err != nil {
 		return err
 	}
+	if author.Language == "" {
+		author.Language = langdetect.Detect(author.Name + "\n" + author.Bio)
+	}
 	return s.repo.Update(author)
 }
 
@@ -34,6 +41,9 @@ func (s *authorService) Delete(id string) error {
 	return s.repo.Delete(id)
 }
 
-func (s *authorService) List() ([]domain.Author, error) {
-	return s.repo.List()
+func (s *authorService) List(filter domain.ListFilter) (*domain.AuthorPage, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.List(filter)
 }
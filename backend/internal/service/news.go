@@ -2,6 +2,7 @@ package service
 
 import (
 	"github.com/oSoloTurk/multiple-kind-search/internal/domain"
+	"github.com/oSoloTurk/multiple-kind-search/internal/langdetect"
 )
 
 type newsService struct {
@@ -16,6 +17,9 @@ func (s *newsService) Create(news *domain.News) error {
 	if err := news.Validate(); err != nil {
 		return err
 	}
+	if news.Language == "" {
+		news.Language = langdetect.Detect(news.Title + "\n" + news.Content)
+	}
 	return s.repo.Create(news)
 }
 
@@ -27,6 +31,9 @@ func (s *newsService) Update(news *domain.News) error {
 	if err := news.Validate(); err != nil {
 		return err
 	}
+	if news.Language == "" {
+		news.Language = langdetect.Detect(news.Title + "\n" + news.Content)
+	}
 	return s.repo.Update(news)
 }
 
@@ -34,6 +41,16 @@ func (s *newsService) Delete(id string) error {
 	return s.repo.Delete(id)
 }
 
-func (s *newsService) List() ([]domain.News, error) {
-	return s.repo.List()
+func (s *newsService) List(filter domain.ListFilter) (*domain.NewsPage, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.List(filter)
+}
+
+func (s *newsService) Related(id string, filter domain.RelatedFilter) ([]domain.News, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.Related(id, filter)
 }
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"github.com/oSoloTurk/multiple-kind-search/internal/domain"
+)
+
+type savedSearchService struct {
+	repo domain.SavedSearchRepository
+}
+
+func NewSavedSearchService(repo domain.SavedSearchRepository) domain.SavedSearchService {
+	return &savedSearchService{repo: repo}
+}
+
+func (s *savedSearchService) Create(search *domain.SavedSearch) error {
+	if err := search.Validate(); err != nil {
+		return err
+	}
+	return s.repo.Create(search)
+}
+
+func (s *savedSearchService) GetByID(id string) (*domain.SavedSearch, error) {
+	return s.repo.GetByID(id)
+}
+
+func (s *savedSearchService) Update(search *domain.SavedSearch) error {
+	if err := search.Validate(); err != nil {
+		return err
+	}
+	return s.repo.Update(search)
+}
+
+func (s *savedSearchService) Delete(id string) error {
+	return s.repo.Delete(id)
+}
+
+func (s *savedSearchService) List(filter domain.ListFilter) (*domain.SavedSearchPage, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.List(filter)
+}
@@ -2,18 +2,81 @@ package service
 
 import (
 	"context"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/oSoloTurk/multiple-kind-search/internal/domain"
+	"github.com/oSoloTurk/multiple-kind-search/internal/logger"
 )
 
 type SearchService struct {
-	repo domain.SearchRepository
+	repo      domain.SearchRepository
+	analytics domain.AnalyticsRepository
 }
 
-func NewSearchService(repo domain.SearchRepository) domain.SearchService {
-	return &SearchService{repo: repo}
+// NewSearchService returns a service that records every search it runs
+// into analytics.
+func NewSearchService(repo domain.SearchRepository, analytics domain.AnalyticsRepository) domain.SearchService {
+	return &SearchService{repo: repo, analytics: analytics}
 }
 
-func (s *SearchService) Search(ctx context.Context, filter domain.SearchFilter) ([]domain.SearchResult, error) {
-	return s.repo.Search(ctx, filter)
+func (s *SearchService) Search(ctx context.Context, filter domain.SearchFilter) (*domain.SearchResponse, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	response, err := s.repo.Search(ctx, filter)
+	event := searchEvent(filter, response, err, time.Since(start))
+	if response != nil {
+		response.SearchID = event.SearchID
+	}
+
+	// The search is recorded before its id is returned, so that clicks on
+	// its results find it. Failing to record it must not fail the search.
+	if err := s.analytics.RecordSearch(event); err != nil {
+		logger.Logger.Warn().Err(err).Str("searchId", event.SearchID).Msg("Failed to record search")
+	}
+
+	return response, err
+}
+
+func (s *SearchService) Suggest(ctx context.Context, filter domain.SuggestFilter) ([]domain.Suggestion, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.Suggest(ctx, filter)
+}
+
+// searchEvent describes a search for analytics, its outcome being either
+// the response or the error.
+func searchEvent(filter domain.SearchFilter, response *domain.SearchResponse, err error, latency time.Duration) *domain.SearchEvent {
+	event := &domain.SearchEvent{
+		SearchID:        uuid.New().String(),
+		Query:           filter.Query,
+		NormalizedQuery: domain.NormalizeQuery(filter.Query),
+		Filters: domain.SearchEventFilters{
+			Types:     filter.Types,
+			Tags:      filter.Tags,
+			AuthorIDs: filter.AuthorIDs,
+			Months:    filter.Months,
+			Boosts:    len(filter.Boosts),
+			Ranking:   filter.Ranking,
+			Language:  filter.Language,
+			Merge:     filter.Merge,
+			From:      filter.From,
+			Size:      filter.Size,
+		},
+		LatencyMillis: latency.Milliseconds(),
+		Timestamp:     time.Now(),
+	}
+	if err != nil {
+		event.Error = err.Error()
+		return event
+	}
+	event.Total = response.Total
+	event.Totals = response.Totals
+	event.Partial = response.Partial
+	event.CorrectedQuery = response.CorrectedQuery
+	return event
 }
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oSoloTurk/multiple-kind-search/internal/domain"
+)
+
+type synonymService struct {
+	repo domain.SynonymRepository
+}
+
+func NewSynonymService(repo domain.SynonymRepository) domain.SynonymService {
+	return &synonymService{repo: repo}
+}
+
+func (s *synonymService) List() (*domain.SynonymVersion, error) {
+	return s.repo.Latest()
+}
+
+func (s *synonymService) GetByID(id string) (*domain.SynonymSet, error) {
+	latest, err := s.repo.Latest()
+	if err != nil {
+		return nil, err
+	}
+	set := latest.Set(id)
+	if set == nil {
+		return nil, fmt.Errorf("%w: synonym set %s", domain.ErrNotFound, id)
+	}
+	return set, nil
+}
+
+func (s *synonymService) Create(set *domain.SynonymSet) error {
+	if err := set.Validate(); err != nil {
+		return err
+	}
+	if set.ID == "" {
+		set.ID = uuid.New().String()
+	}
+
+	latest, err := s.repo.Latest()
+	if err != nil {
+		return err
+	}
+	if latest.Set(set.ID) != nil {
+		return domain.ErrSynonymSetExists
+	}
+	sets := append(append(make([]domain.SynonymSet, 0, len(latest.Sets)+1), latest.Sets...), *set)
+	_, err = s.save(latest, sets, "create "+set.ID)
+	return err
+}
+
+func (s *synonymService) Update(set *domain.SynonymSet) error {
+	if err := set.Validate(); err != nil {
+		return err
+	}
+
+	latest, err := s.repo.Latest()
+	if err != nil {
+		return err
+	}
+	if latest.Set(set.ID) == nil {
+		return fmt.Errorf("%w: synonym set %s", domain.ErrNotFound, set.ID)
+	}
+	sets := make([]domain.SynonymSet, 0, len(latest.Sets))
+	for _, existing := range latest.Sets {
+		if existing.ID == set.ID {
+			existing = *set
+		}
+		sets = append(sets, existing)
+	}
+	_, err = s.save(latest, sets, "update "+set.ID)
+	return err
+}
+
+func (s *synonymService) Delete(id string) error {
+	latest, err := s.repo.Latest()
+	if err != nil {
+		return err
+	}
+	if latest.Set(id) == nil {
+		return fmt.Errorf("%w: synonym set %s", domain.ErrNotFound, id)
+	}
+	sets := make([]domain.SynonymSet, 0, len(latest.Sets))
+	for _, existing := range latest.Sets {
+		if existing.ID != id {
+			sets = append(sets, existing)
+		}
+	}
+	_, err = s.save(latest, sets, "delete "+id)
+	return err
+}
+
+func (s *synonymService) ListVersions(filter domain.ListFilter) (*domain.SynonymVersionPage, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.ListVersions(filter)
+}
+
+// Rollback saves the sets of an earlier version as the newest version.
+func (s *synonymService) Rollback(version int) (*domain.SynonymVersion, error) {
+	target, err := s.repo.GetVersion(version)
+	if err != nil {
+		return nil, err
+	}
+	latest, err := s.repo.Latest()
+	if err != nil {
+		return nil, err
+	}
+	return s.save(latest, target.Sets, fmt.Sprintf("rollback to %d", version))
+}
+
+// save stores sets as the version following latest. A concurrent change
+// saving the same version number first makes it fail with ErrConflict.
+func (s *synonymService) save(latest *domain.SynonymVersion, sets []domain.SynonymSet, change string) (*domain.SynonymVersion, error) {
+	version := &domain.SynonymVersion{
+		Version:   latest.Version + 1,
+		Sets:      sets,
+		Change:    change,
+		CreatedAt: time.Now(),
+	}
+	if err := s.repo.Save(version); err != nil {
+		return nil, err
+	}
+	return version, nil
+}
@@ -10,33 +10,138 @@ done
 echo "Deleting existing indices..."
 curl -X DELETE "http://localhost:9200/authors" 2>/dev/null
 curl -X DELETE "http://localhost:9200/news" 2>/dev/null
+curl -X DELETE "http://localhost:9200/synonym-versions" 2>/dev/null
+curl -X DELETE "http://localhost:9200/search-analytics" 2>/dev/null
+curl -X DELETE "http://localhost:9200/saved-searches" 2>/dev/null
+curl -X DELETE "http://localhost:9200/alerts" 2>/dev/null
+
+# Search synonyms, saved as version 1 the way the API saves every change
+# and applied through the search-synonyms set the analyzers below read
+echo "Creating synonyms..."
+SYNONYM_SETS='[
+  { "id": "kubernetes", "terms": ["k8s", "kubernetes"] },
+  { "id": "ai", "terms": ["ai", "artificial intelligence"] }
+]'
+
+curl -X PUT "http://localhost:9200/synonym-versions" -H "Content-Type: application/json" -d '{
+  "mappings": {
+    "properties": {
+      "version": { "type": "integer" },
+      "sets": { "type": "object", "enabled": false },
+      "change": { "type": "keyword" },
+      "createdAt": { "type": "date" }
+    }
+  }
+}'
+curl -X POST "http://localhost:9200/synonym-versions/_doc/1?refresh=true" -H "Content-Type: application/json" \
+    -d "$(jq -n --argjson sets "$SYNONYM_SETS" '{version: 1, sets: $sets, change: "seed", createdAt: (now | todate)}')"
+curl -X PUT "http://localhost:9200/_synonyms/search-synonyms" -H "Content-Type: application/json" \
+    -d "$(jq -n --argjson sets "$SYNONYM_SETS" '{synonyms_set: [$sets[] | {id, synonyms: (.terms | join(", "))}]}')"
 
 # Create indices with mappings
 echo "Creating indices..."
 
+# Text fields have a subfield per language, named after its code, stemming
+# and folding diacritics the way that language needs. The Turkish analyzer
+# lowercases I to ı and İ to i and strips suffixes after apostrophes before
+# folding, so İstanbul'da, istanbul and Istanbul all meet. Queries are
+# analyzed the same way with the search synonyms added, which the API reloads
+# whenever they change.
+ANALYSIS='{
+  "normalizer": {
+    "lowercase_normalizer": {
+      "type": "custom",
+      "filter": ["lowercase"]
+    }
+  },
+  "filter": {
+    "english_stop": { "type": "stop", "stopwords": "_english_" },
+    "english_stemmer": { "type": "stemmer", "language": "english" },
+    "english_possessive_stemmer": { "type": "stemmer", "language": "possessive_english" },
+    "turkish_lowercase": { "type": "lowercase", "language": "turkish" },
+    "turkish_stop": { "type": "stop", "stopwords": "_turkish_" },
+    "turkish_stemmer": { "type": "stemmer", "language": "turkish" },
+    "search_synonyms": { "type": "synonym_graph", "synonyms_set": "search-synonyms", "updateable": true }
+  },
+  "analyzer": {
+    "english_folded": {
+      "tokenizer": "standard",
+      "filter": ["english_possessive_stemmer", "lowercase", "english_stop", "english_stemmer", "asciifolding"]
+    },
+    "turkish_folded": {
+      "tokenizer": "standard",
+      "filter": ["apostrophe", "turkish_lowercase", "turkish_stop", "turkish_stemmer", "asciifolding"]
+    },
+    "english_folded_search": {
+      "tokenizer": "standard",
+      "filter": ["english_possessive_stemmer", "lowercase", "search_synonyms", "english_stop", "english_stemmer", "asciifolding"]
+    },
+    "turkish_folded_search": {
+      "tokenizer": "standard",
+      "filter": ["apostrophe", "turkish_lowercase", "search_synonyms", "turkish_stop", "turkish_stemmer", "asciifolding"]
+    }
+  }
+}'
+
+LANGUAGE_FIELDS='"en": { "type": "text", "analyzer": "english_folded", "search_analyzer": "english_folded_search" },
+          "tr": { "type": "text", "analyzer": "turkish_folded", "search_analyzer": "turkish_folded_search" }'
+
 # Authors index
 curl -X PUT "http://localhost:9200/authors" -H "Content-Type: application/json" -d '{
+  "settings": { "analysis": '"$ANALYSIS"' },
   "mappings": {
     "properties": {
       "id": { "type": "keyword" },
-      "name": { "type": "text" },
-      "bio": { "type": "text" },
+      "name": {
+        "type": "text",
+        "copy_to": "nameSuggest",
+        "fields": {
+          "keyword": { "type": "keyword", "normalizer": "lowercase_normalizer" },
+          '"$LANGUAGE_FIELDS"'
+        }
+      },
+      "nameSuggest": { "type": "search_as_you_type" },
+      "bio": {
+        "type": "text",
+        "fields": {
+          '"$LANGUAGE_FIELDS"'
+        }
+      },
+      "language": { "type": "keyword" },
       "imageUrl": { "type": "keyword" },
+      "articleCount": { "type": "integer" },
       "createdAt": { "type": "date" },
       "updatedAt": { "type": "date" }
     }
   }
 }'
 
-# News index
+# News index, carrying the author name and image so that searching and showing
+# news needs no author lookup
 curl -X PUT "http://localhost:9200/news" -H "Content-Type: application/json" -d '{
+  "settings": { "analysis": '"$ANALYSIS"' },
   "mappings": {
     "properties": {
       "id": { "type": "keyword" },
-      "title": { "type": "text" },
-      "content": { "type": "text" },
-      "authorId": { "type": "keyword" },
-      "tags": { "type": "keyword" },
+      "title": {
+        "type": "text",
+        "copy_to": "titleSuggest",
+        "fields": {
+          '"$LANGUAGE_FIELDS"'
+        }
+      },
+      "titleSuggest": { "type": "search_as_you_type" },
+      "content": {
+        "type": "text",
+        "fields": {
+          '"$LANGUAGE_FIELDS"'
+        }
+      },
+      "language": { "type": "keyword" },
+      "authorID": { "type": "keyword" },
+      "authorName": { "type": "keyword", "normalizer": "lowercase_normalizer" },
+      "authorImageUrl": { "type": "keyword", "index": false },
+      "tags": { "type": "keyword", "normalizer": "lowercase_normalizer" },
       "imageUrl": { "type": "keyword" },
       "createdAt": { "type": "date" },
       "updatedAt": { "type": "date" }
@@ -44,21 +149,100 @@ curl -X PUT "http://localhost:9200/news" -H "Content-Type: application/json" -d
   }
 }'
 
+# Search analytics, one document per search or click told apart by event
+curl -X PUT "http://localhost:9200/search-analytics" -H "Content-Type: application/json" -d '{
+  "mappings": {
+    "properties": {
+      "event": { "type": "keyword" },
+      "searchId": { "type": "keyword" },
+      "query": { "type": "text" },
+      "normalizedQuery": { "type": "keyword" },
+      "filters": { "type": "object", "enabled": false },
+      "total": { "type": "long" },
+      "totals": { "type": "object" },
+      "partial": { "type": "boolean" },
+      "correctedQuery": { "type": "keyword" },
+      "latencyMs": { "type": "long" },
+      "error": { "type": "keyword" },
+      "resultId": { "type": "keyword" },
+      "type": { "type": "keyword" },
+      "position": { "type": "integer" },
+      "timestamp": { "type": "date" }
+    }
+  }
+}'
+
+# Saved searches, each holding the news query it stands for in the match
+# percolator field. The news fields those queries read are mapped as in the
+# news index so that articles percolate the way they are searched.
+curl -X PUT "http://localhost:9200/saved-searches" -H "Content-Type: application/json" -d '{
+  "settings": { "analysis": '"$ANALYSIS"' },
+  "mappings": {
+    "dynamic": false,
+    "properties": {
+      "id": { "type": "keyword" },
+      "name": { "type": "keyword" },
+      "query": { "type": "text" },
+      "tagMatch": { "type": "keyword" },
+      "authorIds": { "type": "keyword" },
+      "match": { "type": "percolator" },
+      "title": {
+        "type": "text",
+        "fields": {
+          '"$LANGUAGE_FIELDS"'
+        }
+      },
+      "content": {
+        "type": "text",
+        "fields": {
+          '"$LANGUAGE_FIELDS"'
+        }
+      },
+      "language": { "type": "keyword" },
+      "authorID": { "type": "keyword" },
+      "authorName": { "type": "keyword", "normalizer": "lowercase_normalizer" },
+      "tags": { "type": "keyword", "normalizer": "lowercase_normalizer" },
+      "createdAt": { "type": "date" },
+      "updatedAt": { "type": "date" }
+    }
+  }
+}'
+
+# Alerts raised by articles matching saved searches
+curl -X PUT "http://localhost:9200/alerts" -H "Content-Type: application/json" -d '{
+  "mappings": {
+    "properties": {
+      "id": { "type": "keyword" },
+      "savedSearchId": { "type": "keyword" },
+      "savedSearchName": { "type": "keyword" },
+      "newsId": { "type": "keyword" },
+      "newsTitle": { "type": "text" },
+      "event": { "type": "keyword" },
+      "createdAt": { "type": "date" }
+    }
+  }
+}'
+
 echo "Loading data..."
 
-# Load authors data
-jq -c '.[]' authors.json | while read -r line; do
+# Load authors data with the number of news each wrote, which the API keeps
+# up to date as news are written
+jq -c --slurpfile news news.json \
+    '.[] | .id as $id | . + {articleCount: ([$news[0][] | select(.authorID == $id)] | length)}' \
+    authors.json | while read -r line; do
     id=$(echo $line | jq -r '.id')
-    # Add timestamps if not present
-    line=$(echo $line | jq '. + {createdAt: (now | todate), updatedAt: (now | todate)}')
+    # Add timestamps if not present, and the language the seed data is written in
+    line=$(echo $line | jq '. + {language: (.language // "en"), createdAt: (now | todate), updatedAt: (now | todate)}')
     curl -X POST "http://localhost:9200/authors/_doc/$id" -H "Content-Type: application/json" -d "$line"
 done
 
-# Load news data
-jq -c '.[]' news.json | while read -r line; do
+# Load news data with the name and image of each article's author
+jq -c --slurpfile authors authors.json \
+    '.[] | .authorID as $id | ($authors[0][] | select(.id == $id)) as $author | . + {authorName: $author.name, authorImageUrl: $author.imageUrl}' \
+    news.json | while read -r line; do
     id=$(echo $line | jq -r '.id')
-    # Add timestamps if not present
-    line=$(echo $line | jq '. + {createdAt: (now | todate), updatedAt: (now | todate)}')
+    # Add timestamps if not present, and the language the seed data is written in
+    line=$(echo $line | jq '. + {language: (.language // "en"), createdAt: (now | todate), updatedAt: (now | todate)}')
     curl -X POST "http://localhost:9200/news/_doc/$id" -H "Content-Type: application/json" -d "$line"
 done
 
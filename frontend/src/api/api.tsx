@@ -1,10 +1,13 @@
 import axios from './config';
 
+export type Language = 'en' | 'tr';
+
 export interface Author {
   id?: string;
   name: string;
   bio?: string;
   imageUrl?: string;
+  language?: Language;
 }
 
 export interface News {
@@ -12,16 +15,95 @@ export interface News {
   title: string;
   content: string;
   authorID: string;
+  authorName?: string;
+  authorImageUrl?: string;
   tags?: string[];
   imageUrl?: string;
+  language?: Language;
+}
+
+export interface Page<T> {
+  items: T[];
+  next?: string;
+}
+
+export interface HighlightSpan {
+  start: number;
+  end: number;
+}
+
+export interface HighlightFragment {
+  text: string;
+  matches: HighlightSpan[];
+}
+
+export interface ResultAuthor {
+  id: string;
+  name?: string;
+  imageUrl?: string;
+}
+
+export interface FieldMatch {
+  field: string;
+  term: string;
+  score: number;
+}
+
+export interface ScoreExplanation {
+  score: number;
+  matches: FieldMatch[];
+  authorBoost: number;
+  decay?: number;
+  merge: string;
+  normalized: number;
+  weight: number;
 }
 
 export interface SearchResult {
   id: string;
   title: string;
   content: string;
+  highlights?: Record<string, HighlightFragment[]>;
   score: number;
   type: string;
+  imageUrl?: string;
+  author?: ResultAuthor;
+  createdAt?: string;
+  tags?: string[];
+  articleCount?: number;
+  explanation?: ScoreExplanation;
+}
+
+export interface Suggestion {
+  text: string;
+  type: string;
+  id?: string;
+}
+
+export interface KindStatus {
+  state: 'ok' | 'failed' | 'timed_out';
+  error?: string;
+}
+
+export interface FacetBucket {
+  key: string;
+  label?: string;
+  count: number;
+}
+
+export interface SearchResponse {
+  searchId?: string;
+  results: SearchResult[];
+  total: number;
+  totals: Record<string, number>;
+  status: Record<string, KindStatus>;
+  partial: boolean;
+  facets?: Record<string, FacetBucket[]>;
+  didYouMean?: string;
+  correctedQuery?: string;
+  unresolvedAuthors?: string[];
+  from: number;
+  size: number;
 }
 
 export const newsApi = {
@@ -40,13 +122,22 @@ export const newsApi = {
     return response.data;
   },
 
-  listNews: async () => {
-    const response = await axios.get<News[]>('/api/news');
+  listNews: async (cursor?: string) => {
+    const response = await axios.get<Page<News>>('/api/news', {
+      params: { cursor }
+    });
     return response.data;
   },
 
   deleteNews: async (id: string) => {
     await axios.delete(`/api/news/${id}`);
+  },
+
+  relatedNews: async (id: string, options: { sameAuthor?: boolean; sameTags?: boolean } = {}) => {
+    const response = await axios.get<News[]>(`/api/news/${id}/related`, {
+      params: options
+    });
+    return response.data;
   }
 };
 
@@ -66,8 +157,10 @@ export const authorApi = {
     return response.data;
   },
 
-  listAuthors: async () => {
-    const response = await axios.get<Author[]>('/api/authors');
+  listAuthors: async (cursor?: string) => {
+    const response = await axios.get<Page<Author>>('/api/authors', {
+      params: { cursor }
+    });
     return response.data;
   },
 
@@ -76,10 +169,27 @@ export const authorApi = {
   }
 };
 
+export type RankingProfile = 'relevant' | 'fresh';
+
 export const searchApi = {
-  search: async ({ q, username }: { q: string; username: string }) => {
-    const response = await axios.get<SearchResult[]>('/api/search', {
-      params: { q, username }
+  search: async ({ q, username, from = 0, size = 10, types, autocorrect, ranking, explain }: { q: string; username?: string; from?: number; size?: number; types?: string[]; autocorrect?: boolean; ranking?: RankingProfile; explain?: boolean }) => {
+    const response = await axios.get<SearchResponse>('/api/search', {
+      params: { q, username: username || undefined, from, size, types: types?.join(','), autocorrect, ranking, explain: explain || undefined }
+    });
+    return response.data;
+  }
+};
+
+export const analyticsApi = {
+  recordClick: async (click: { searchId: string; resultId: string; type: string; position: number }) => {
+    await axios.post('/api/analytics/clicks', click);
+  }
+};
+
+export const suggestApi = {
+  suggest: async (q: string, size: number = 8) => {
+    const response = await axios.get<Suggestion[]>('/api/suggest', {
+      params: { q, size }
     });
     return response.data;
   }
@@ -1,6 +1,6 @@
 import React, { useState, useEffect } from 'react';
 import { useParams, useNavigate } from 'react-router-dom';
-import { newsApi, authorApi, News, Author } from '../api/api';
+import { newsApi, authorApi, News, Author, Page } from '../api/api';
 import { Button, CircularProgress } from '@mui/material';
 import './ListPage.css';
 import { ThemeProvider, createTheme } from "@mui/material/styles";
@@ -40,22 +40,26 @@ const ListPage: React.FC = () => {
   const navigate = useNavigate();
   const [items, setItems] = useState<(News | Author)[]>([]);
   const [loading, setLoading] = useState(true);
+  const [nextCursor, setNextCursor] = useState<string | undefined>(undefined);
   const searchTerm = new URLSearchParams(window.location.search).get('query') || '';
 
   useEffect(() => {
     loadItems();
   }, [type, searchTerm]);
 
-  const loadItems = async () => {
-    setItems([]);
+  const loadItems = async (cursor?: string) => {
+    if (!cursor) {
+      setItems([]);
+    }
     setLoading(true);
     try {
-      let data: (News | Author)[] = [];
+      let page: Page<News | Author> = { items: [] };
       if (type === 'news') {
-        data = await newsApi.listNews();
+        page = await newsApi.listNews(cursor);
       } else if (type === 'authors') {
-        data = await authorApi.listAuthors();
+        page = await authorApi.listAuthors(cursor);
       }
+      let data = page.items || [];
 
       if (searchTerm) {
         const lowercasedTerm = searchTerm.toLowerCase();
@@ -70,7 +74,8 @@ const ListPage: React.FC = () => {
         });
       }
 
-      setItems(data);
+      setItems(previous => (cursor ? [...previous, ...data] : data));
+      setNextCursor(page.next);
     } catch (error) {
       console.error('Error loading items:', error);
     }
@@ -119,9 +124,10 @@ const ListPage: React.FC = () => {
             </Button>
           </Box>
 
-          {loading ? (
+          {loading && items.length === 0 ? (
             <CircularProgress />
           ) : (
+            <>
             <Grid container spacing={3}>
               {items.map((item) => (
                 <Grid item xs={12} sm={6} md={4} key={item.id}>
@@ -140,13 +146,15 @@ const ListPage: React.FC = () => {
                               {(item as News).title}
                             </Typography>
                             <Typography variant="body2" color="text.secondary" gutterBottom>
-                              By {/* Add author name if available */}
+                              By {(item as News).authorName || 'Unknown author'}
                             </Typography>
                             <Typography variant="body2" color="text.primary">
                               {(item as News).content?.substring(0, 150)}...
                             </Typography>
                             <Box mt={2} display="flex" flexWrap="wrap" gap={1}>
-                              {/* Display tags if available */}
+                              {(item as News).tags?.map((tag) => (
+                                <Chip key={tag} label={tag} size="small" />
+                              ))}
                             </Box>
                           </CardContent>
                         </>
@@ -181,6 +189,14 @@ const ListPage: React.FC = () => {
                 </Grid>
               ))}
             </Grid>
+            {nextCursor && (
+              <Box display="flex" justifyContent="center" mt={4}>
+                <Button variant="outlined" disabled={loading} onClick={() => loadItems(nextCursor)}>
+                  Load More
+                </Button>
+              </Box>
+            )}
+            </>
           )}
         </div>
       </Box>
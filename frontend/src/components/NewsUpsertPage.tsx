@@ -49,6 +49,7 @@ function NewsUpsertPage() {
   });
   const [availableAuthors, setAvailableAuthors] = useState<Author[]>([]);
   const [tagInput, setTagInput] = useState('');
+  const [related, setRelated] = useState<News[]>([]);
   const ref = React.useRef<MDXEditorMethods>(null)
 
   useEffect(() => {
@@ -60,7 +61,13 @@ function NewsUpsertPage() {
 
   const loadAuthors = async () => {
     try {
-      const authors = await authorApi.listAuthors();
+      const authors: Author[] = [];
+      let cursor: string | undefined;
+      do {
+        const page = await authorApi.listAuthors(cursor);
+        authors.push(...page.items);
+        cursor = page.next;
+      } while (cursor);
       setAvailableAuthors(authors);
     } catch (error) {
       console.error('Error loading authors:', error);
@@ -72,6 +79,7 @@ function NewsUpsertPage() {
       if (id) {
         const data = await newsApi.getNews(id);
         setNews(data);
+        setRelated(await newsApi.relatedNews(id));
       }
     } catch (error) {
       console.error('Error fetching news:', error);
@@ -223,6 +231,21 @@ function NewsUpsertPage() {
           </select>
         </div>
 
+        <div className="form-group">
+          <label htmlFor="language">Language</label>
+          <select
+            id="language"
+            name="language"
+            className="form-control"
+            value={news.language || ''}
+            onChange={handleNewsChange}
+          >
+            <option value="">Detect automatically</option>
+            <option value="en">English</option>
+            <option value="tr">Turkish</option>
+          </select>
+        </div>
+
         <div className="form-group">
           <label htmlFor="imageUrl">Image URL</label>
           <input
@@ -293,6 +316,20 @@ function NewsUpsertPage() {
           </button>
         </div>
       </form>
+
+      {related.length > 0 && (
+        <div className="related-news">
+          <h2>Related Articles</h2>
+          <ul>
+            {related.map((item) => (
+              <li key={item.id}>
+                <a href={`/edit/news/${item.id}`}>{item.title}</a>
+                {item.authorName && ` by ${item.authorName}`}
+              </li>
+            ))}
+          </ul>
+        </div>
+      )}
     </div>
   );
 }
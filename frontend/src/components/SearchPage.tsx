@@ -1,35 +1,138 @@
-import React, { useState } from 'react';
+import React, { useState, useEffect } from 'react';
+import { isAxiosError } from 'axios';
 import { useNavigate } from 'react-router-dom';
-import { TextField, Button, CircularProgress } from '@mui/material';
+import { TextField, Button, CircularProgress, Select, MenuItem, Checkbox, FormControlLabel } from '@mui/material';
 import './SearchPage.css';
-import { searchApi, SearchResult } from '../api/api';
+import { searchApi, suggestApi, analyticsApi, SearchResult, Suggestion, HighlightFragment, RankingProfile, ScoreExplanation } from '../api/api';
+
+const PAGE_SIZE = 10;
+const SUGGEST_DELAY_MS = 150;
+
+// Match offsets count code points, so split the text the same way
+const Highlighted: React.FC<{ fragment: HighlightFragment }> = ({ fragment }) => {
+  const chars = Array.from(fragment.text);
+  const parts: React.ReactNode[] = [];
+  let position = 0;
+  fragment.matches.forEach((match, i) => {
+    parts.push(chars.slice(position, match.start).join(''));
+    parts.push(<em key={i}>{chars.slice(match.start, match.end).join('')}</em>);
+    position = match.end;
+  });
+  parts.push(chars.slice(position).join(''));
+  return <>{parts}</>;
+};
+
+const ResultText: React.FC<{ fragments?: HighlightFragment[]; text: string }> = ({ fragments, text }) => {
+  if (!fragments || fragments.length === 0) {
+    return <>{text}</>;
+  }
+  return (
+    <>
+      {fragments.map((fragment, i) => (
+        <React.Fragment key={i}>
+          {i > 0 && ' … '}
+          <Highlighted fragment={fragment} />
+        </React.Fragment>
+      ))}
+    </>
+  );
+};
+
+const ScoreBreakdown: React.FC<{ explanation: ScoreExplanation; score: number }> = ({ explanation, score }) => (
+  <details className="score-breakdown">
+    <summary>Score {score.toFixed(3)}</summary>
+    <ul>
+      {explanation.matches.map((match, i) => (
+        <li key={i}>{match.field}: {match.term} <strong>{match.score.toFixed(3)}</strong></li>
+      ))}
+      {explanation.authorBoost > 0 && <li>Author boost <strong>{explanation.authorBoost.toFixed(3)}</strong></li>}
+      {explanation.decay !== undefined && <li>Recency decay ×{explanation.decay.toFixed(3)}</li>}
+      <li>Elasticsearch score {explanation.score.toFixed(3)}</li>
+      <li>Merged by {explanation.merge} to {explanation.normalized.toFixed(3)}, weight ×{explanation.weight}</li>
+    </ul>
+  </details>
+);
 
 const SearchPage: React.FC = () => {
   const [query, setQuery] = useState('');
   const [username, setUsername] = useState('');
+  const [ranking, setRanking] = useState<RankingProfile>('relevant');
+  const [explain, setExplain] = useState(false);
+  const [searchId, setSearchId] = useState('');
   const [results, setResults] = useState<SearchResult[]>([]);
+  const [total, setTotal] = useState(0);
+  const [from, setFrom] = useState(0);
+  const [partial, setPartial] = useState(false);
+  const [unresolvedAuthors, setUnresolvedAuthors] = useState<string[]>([]);
+  const [didYouMean, setDidYouMean] = useState('');
+  const [correctedQuery, setCorrectedQuery] = useState('');
+  const [queryError, setQueryError] = useState('');
   const [isLoading, setIsLoading] = useState(false);
+  const [suggestions, setSuggestions] = useState<Suggestion[]>([]);
   const navigate = useNavigate();
 
-  const handleSearch = async (searchQuery: string) => {
-    if (!username) {
-      alert('Username is required');
+  useEffect(() => {
+    const prefix = query.trim();
+    if (!prefix) {
+      setSuggestions([]);
       return;
     }
-    
+
+    // Wait for a pause in typing before asking for suggestions
+    const timer = setTimeout(async () => {
+      try {
+        setSuggestions(await suggestApi.suggest(prefix));
+      } catch (error) {
+        console.error('Error fetching suggestions:', error);
+        setSuggestions([]);
+      }
+    }, SUGGEST_DELAY_MS);
+
+    return () => clearTimeout(timer);
+  }, [query]);
+
+  const handleSuggestion = (suggestion: Suggestion) => {
+    setQuery(suggestion.text);
+    setSuggestions([]);
+    handleSearch(suggestion.text);
+  };
+
+  const handleSearch = async (searchQuery: string, offset: number = 0, profile: RankingProfile = ranking) => {
+    setSuggestions([]);
     setIsLoading(true);
+    setQueryError('');
     try {
-      const data = await searchApi.search({ q: searchQuery, username });
-      // Filter only news results
-      setResults((data || []))
+      const data = await searchApi.search({ q: searchQuery, username, from: offset, size: PAGE_SIZE, autocorrect: true, ranking: profile, explain });
+      setResults(data?.results || []);
+      setTotal(data?.total || 0);
+      setFrom(offset);
+      setPartial(!!data?.partial);
+      setUnresolvedAuthors(data?.unresolvedAuthors || []);
+      setDidYouMean(data?.didYouMean || '');
+      setCorrectedQuery(data?.correctedQuery || '');
+      setSearchId(data?.searchId || '');
     } catch (error) {
       console.error('Error searching:', error);
+      if (isAxiosError(error) && error.response?.status === 400) {
+        setQueryError(error.response.data?.error || 'Invalid query');
+      }
       setResults([]);
+      setTotal(0);
+      setPartial(false);
+      setUnresolvedAuthors([]);
+      setDidYouMean('');
+      setCorrectedQuery('');
+      setSearchId('');
     }
     setIsLoading(false);
   };
 
-  const handleEdit = (result: SearchResult) => {
+  const handleEdit = (result: SearchResult, position: number) => {
+    if (searchId) {
+      // Click tracking must never keep the user from the result
+      analyticsApi.recordClick({ searchId, resultId: result.id, type: result.type, position: from + position })
+        .catch((error) => console.error('Error recording click:', error));
+    }
     navigate(`/edit/news/${result.id}`);
   };
 
@@ -41,16 +144,33 @@ const SearchPage: React.FC = () => {
           variant="outlined"
           value={username}
           onChange={(e) => setUsername(e.target.value)}
-          placeholder="Enter username..."
+          placeholder="Preferred author (optional)..."
           className="search-input"
         />
         <TextField
           variant="outlined"
           value={query}
           onChange={(e) => setQuery(e.target.value)}
-          placeholder="Search for news..."
+          placeholder='Search for news, e.g. "edge computing" tag:cloud -docker'
           className="search-input"
         />
+        <Select
+          value={ranking}
+          onChange={(e) => {
+            const profile = e.target.value as RankingProfile;
+            setRanking(profile);
+            if (query) {
+              handleSearch(query, 0, profile);
+            }
+          }}
+        >
+          <MenuItem value="relevant">Most relevant</MenuItem>
+          <MenuItem value="fresh">Freshest</MenuItem>
+        </Select>
+        <FormControlLabel
+          control={<Checkbox checked={explain} onChange={(e) => setExplain(e.target.checked)} />}
+          label="Explain scores"
+        />
         <Button 
           variant="contained" 
           color="primary" 
@@ -60,28 +180,100 @@ const SearchPage: React.FC = () => {
         </Button>
       </div>
 
+      {suggestions.length > 0 && (
+        <div className="suggestions-container">
+          {suggestions.map((suggestion) => (
+            <div
+              key={`${suggestion.type}-${suggestion.id || suggestion.text}`}
+              className="suggestion-item"
+              onClick={() => handleSuggestion(suggestion)}
+            >
+              <span>{suggestion.text}</span>
+              <span className="suggestion-type">{suggestion.type}</span>
+            </div>
+          ))}
+        </div>
+      )}
+
+      {!isLoading && queryError && (
+        <div className="partial-results">{queryError}</div>
+      )}
+
+      {!isLoading && partial && (
+        <div className="partial-results">Some results could not be loaded</div>
+      )}
+
+      {!isLoading && correctedQuery && (
+        <div className="did-you-mean">Showing results for <strong>{correctedQuery}</strong></div>
+      )}
+
+      {!isLoading && !correctedQuery && didYouMean && (
+        <div className="did-you-mean">
+          Did you mean{' '}
+          <span className="did-you-mean-link" onClick={() => { setQuery(didYouMean); handleSearch(didYouMean); }}>
+            {didYouMean}
+          </span>
+          ?
+        </div>
+      )}
+
+      {!isLoading && unresolvedAuthors.length > 0 && (
+        <div className="partial-results">Author not found: {unresolvedAuthors.join(', ')}</div>
+      )}
+
       {isLoading ? (
         <CircularProgress />
       ) : (
         results.length > 0 ? (
         <div className="results-container">
-          {results.map((result: SearchResult) => (
+          {results.map((result: SearchResult, position: number) => (
             <div key={result.id} className="result-card">
               {result.type === 'author' ? (
                 <div className="author-card">
-                  <h2 dangerouslySetInnerHTML={{ __html: result.title }} />
-                  <p  dangerouslySetInnerHTML={{ __html: result.content }} />
-                  <Button onClick={() => handleEdit(result)}>Edit Author</Button>
+                  <h2><ResultText fragments={result.highlights?.title} text={result.title} /></h2>
+                  {result.articleCount !== undefined && (
+                    <div className="author">{result.articleCount} {result.articleCount === 1 ? 'article' : 'articles'}</div>
+                  )}
+                  <p><ResultText fragments={result.highlights?.content} text={result.content} /></p>
+                  <Button onClick={() => handleEdit(result, position)}>Edit Author</Button>
                 </div>
               ) : (
                 <div className="news-card">
-                  <h2 dangerouslySetInnerHTML={{ __html: result.title }} />
-                  <p  dangerouslySetInnerHTML={{ __html: result.content }} />
-                  <Button onClick={() => handleEdit(result)}>Edit News</Button>
+                  <h2><ResultText fragments={result.highlights?.title} text={result.title} /></h2>
+                  {result.author?.name && (
+                    <div className="author byline">
+                      {result.author.imageUrl && <img className="byline-image" src={result.author.imageUrl} alt={result.author.name} />}
+                      By {result.author.name}
+                      {result.createdAt && ` · ${new Date(result.createdAt).toLocaleDateString()}`}
+                    </div>
+                  )}
+                  <p><ResultText fragments={result.highlights?.content} text={result.content} /></p>
+                  {result.tags && result.tags.length > 0 && (
+                    <div className="result-tags">
+                      {result.tags.map((tag) => <span key={tag} className="result-tag">{tag}</span>)}
+                    </div>
+                  )}
+                  <Button onClick={() => handleEdit(result, position)}>Edit News</Button>
                 </div>
               )}
+              {result.explanation && <ScoreBreakdown explanation={result.explanation} score={result.score} />}
             </div>
           ))}
+          <div className="pagination">
+            <Button
+              disabled={from === 0}
+              onClick={() => handleSearch(query, Math.max(from - PAGE_SIZE, 0))}
+            >
+              Previous
+            </Button>
+            <span>{from + 1}-{from + results.length} of {total}</span>
+            <Button
+              disabled={from + PAGE_SIZE >= total}
+              onClick={() => handleSearch(query, from + PAGE_SIZE)}
+            >
+              Next
+            </Button>
+          </div>
         </div>
       ) : (
         query && <div className="no-results">No results found</div>